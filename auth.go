@@ -6,9 +6,11 @@
 package libecto
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -39,27 +41,19 @@ func ParseAPIKey(apiKey string) (id string, secret []byte, err error) {
 	return id, secret, nil
 }
 
+// tokenLifetime is how long tokens produced by this package remain valid.
+// Ghost rejects Admin API tokens with a lifetime longer than 5 minutes.
+const tokenLifetime = 5 * time.Minute
+
+// tokenRefreshMargin is how long before expiry a cached token is replaced,
+// so that a token is never sent just as it expires.
+const tokenRefreshMargin = time.Minute
+
 // GenerateToken creates a JWT token for Ghost Admin API authentication.
 // The token is signed with HS256 and is valid for 5 minutes.
 // It returns an error if the API key is invalid.
 func GenerateToken(apiKey string) (string, error) {
-	id, secret, err := ParseAPIKey(apiKey)
-	if err != nil {
-		return "", err
-	}
-
-	now := time.Now()
-	claims := jwt.MapClaims{
-		"iat": now.Unix(),
-		"exp": now.Add(5 * time.Minute).Unix(),
-		"aud": "/admin/",
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	token.Header["kid"] = id
-	token.Header["typ"] = "JWT"
-
-	return token.SignedString(secret)
+	return GenerateTokenWithTime(apiKey, time.Now())
 }
 
 // GenerateTokenWithTime creates a JWT token with a specific timestamp.
@@ -70,10 +64,13 @@ func GenerateTokenWithTime(apiKey string, now time.Time) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return signToken(id, secret, now)
+}
 
+func signToken(id string, secret []byte, now time.Time) (string, error) {
 	claims := jwt.MapClaims{
 		"iat": now.Unix(),
-		"exp": now.Add(5 * time.Minute).Unix(),
+		"exp": now.Add(tokenLifetime).Unix(),
 		"aud": "/admin/",
 	}
 
@@ -83,3 +80,65 @@ func GenerateTokenWithTime(apiKey string, now time.Time) (string, error) {
 
 	return token.SignedString(secret)
 }
+
+// TokenSource supplies the tokens a Client sends in its Authorization header.
+// Implementations must be safe for concurrent use. Use WithTokenSource to
+// obtain tokens from a secrets manager or signing sidecar instead of an API key.
+type TokenSource interface {
+	// Token returns a valid Admin API token.
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc adapts an ordinary function to the TokenSource interface.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// NewTokenSource returns a TokenSource that signs tokens with apiKey.
+// The API key is parsed once, and each signed token is cached and reused
+// until one minute before it expires. It is safe for concurrent use.
+func NewTokenSource(apiKey string) TokenSource {
+	return &keyTokenSource{apiKey: apiKey, now: time.Now}
+}
+
+type keyTokenSource struct {
+	apiKey string
+	now    func() time.Time
+
+	mu      sync.Mutex
+	parsed  bool
+	id      string
+	secret  []byte
+	err     error
+	token   string
+	refresh time.Time
+}
+
+func (s *keyTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.parsed {
+		s.id, s.secret, s.err = ParseAPIKey(s.apiKey)
+		s.parsed = true
+	}
+	if s.err != nil {
+		return "", s.err
+	}
+
+	now := s.now()
+	if s.token != "" && now.Before(s.refresh) {
+		return s.token, nil
+	}
+
+	token, err := signToken(s.id, s.secret, now)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.refresh = now.Add(tokenLifetime - tokenRefreshMargin)
+	return token, nil
+}
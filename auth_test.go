@@ -1,7 +1,12 @@
 package libecto
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -289,3 +294,113 @@ func BenchmarkGenerateToken(b *testing.B) {
 		_, _ = GenerateToken(validAPIKey)
 	}
 }
+
+// TokenSource tests
+
+func TestNewTokenSource_CachesToken(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	ts := NewTokenSource(validAPIKey).(*keyTokenSource)
+	ts.now = func() time.Time { return now }
+
+	token1, err := ts.Token(context.Background())
+	require.NoError(t, err)
+
+	now = now.Add(3 * time.Minute)
+	token2, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token1, token2, "token should be reused before the refresh margin")
+
+	now = now.Add(90 * time.Second)
+	token3, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, token1, token3, "token should be refreshed within a minute of expiry")
+
+	expected, err := GenerateTokenWithTime(validAPIKey, now)
+	require.NoError(t, err)
+	assert.Equal(t, expected, token3)
+}
+
+func TestNewTokenSource_InvalidKey(t *testing.T) {
+	ts := NewTokenSource(malformedKey)
+	_, err := ts.Token(context.Background())
+	require.Error(t, err)
+	_, err = ts.Token(context.Background())
+	require.Error(t, err)
+}
+
+func TestNewTokenSource_Concurrent(t *testing.T) {
+	ts := NewTokenSource(validAPIKey)
+	var wg sync.WaitGroup
+	tokens := make([]string, 20)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := ts.Token(context.Background())
+			assert.NoError(t, err)
+			tokens[i] = token
+		}(i)
+	}
+	wg.Wait()
+	for _, token := range tokens {
+		assert.Equal(t, tokens[0], token)
+	}
+}
+
+func TestTokenSourceFunc(t *testing.T) {
+	ts := TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return "from-sidecar", nil
+	})
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "from-sidecar", token)
+}
+
+func TestClient_WithTokenSource(t *testing.T) {
+	var calls int
+	ts := TokenSourceFunc(func(ctx context.Context) (string, error) {
+		calls++
+		return "sidecar-token", nil
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Ghost sidecar-token", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"site":{"title":"Blog"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", WithTokenSource(ts))
+	_, err := client.GetSite()
+	require.NoError(t, err)
+	_, err = client.GetSite()
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_TokenSourceError(t *testing.T) {
+	ts := TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return "", errors.New("vault sealed")
+	})
+	client := NewClient("http://localhost", "", WithTokenSource(ts))
+	_, err := client.GetSite()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "generating token")
+	assert.Contains(t, err.Error(), "vault sealed")
+}
+
+func TestClient_ReusesCachedToken(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"site":{}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, validAPIKey)
+	for i := 0; i < 3; i++ {
+		_, err := client.GetSite()
+		require.NoError(t, err)
+	}
+	require.Len(t, seen, 3)
+	assert.Equal(t, seen[0], seen[1])
+	assert.Equal(t, seen[0], seen[2])
+}
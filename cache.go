@@ -0,0 +1,160 @@
+package libecto
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ResponseCache stores GET responses and revalidates them with conditional
+// requests. Responses carrying an ETag or Last-Modified header are cached by
+// URL; later GETs for the same URL send If-None-Match / If-Modified-Since, and
+// a 304 Not Modified reply is answered from the cache.
+//
+// Any non-GET call made through a client using the cache invalidates every
+// cached entry for the same resource type, so a CreatePost or UpdatePost
+// drops cached post listings and lookups while tags stay cached.
+//
+// A ResponseCache is safe for concurrent use and may be shared by several clients.
+type ResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	stats   CacheStats
+}
+
+// cacheEntry is a cached response and its validators.
+type cacheEntry struct {
+	resource     string
+	etag         string
+	lastModified string
+	resp         *Response
+}
+
+// CacheStats reports response cache usage.
+type CacheStats struct {
+	// Hits is the number of requests answered from the cache after a 304.
+	Hits int64
+	// Misses is the number of GET requests that returned a full response.
+	Misses int64
+	// Entries is the number of responses currently cached.
+	Entries int
+}
+
+// NewResponseCache creates an empty response cache.
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{entries: map[string]*cacheEntry{}}
+}
+
+// WithResponseCache enables conditional GET caching using cache.
+func WithResponseCache(cache *ResponseCache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// Stats returns a snapshot of the cache statistics.
+func (rc *ResponseCache) Stats() CacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	stats := rc.stats
+	stats.Entries = len(rc.entries)
+	return stats
+}
+
+// Invalidate removes every cached response for resource (e.g., "posts").
+func (rc *ResponseCache) Invalidate(resource string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for key, entry := range rc.entries {
+		if entry.resource == resource {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// Clear removes every cached response.
+func (rc *ResponseCache) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = map[string]*cacheEntry{}
+}
+
+// wrap returns a Handler that serves GETs under baseURL through the cache.
+func (rc *ResponseCache) wrap(baseURL string, next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		if req.Method != http.MethodGet {
+			resp, err := next(ctx, req)
+			rc.Invalidate(req.resource())
+			return resp, err
+		}
+
+		key := baseURL + req.Path
+		rc.mu.Lock()
+		entry := rc.entries[key]
+		rc.mu.Unlock()
+
+		if entry != nil {
+			if entry.etag != "" && req.Header.Get("If-None-Match") == "" {
+				req.Header.Set("If-None-Match", entry.etag)
+			}
+			if entry.lastModified != "" && req.Header.Get("If-Modified-Since") == "" {
+				req.Header.Set("If-Modified-Since", entry.lastModified)
+			}
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusNotModified {
+			rc.mu.Lock()
+			if entry != nil && rc.entries[key] == entry {
+				rc.stats.Hits++
+				rc.mu.Unlock()
+				return entry.resp.clone(), nil
+			}
+			rc.mu.Unlock()
+
+			// There is nothing to answer the 304 from: the validators were set
+			// by a middleware, or the entry was invalidated while the request
+			// was in flight. Ask again for the full response.
+			req.Header.Del("If-None-Match")
+			req.Header.Del("If-Modified-Since")
+			if resp, err = next(ctx, req); err != nil {
+				return nil, err
+			}
+			if resp.StatusCode == http.StatusNotModified {
+				return nil, fmt.Errorf("%s %s: 304 Not Modified returned for an unconditional request", req.Method, req.Path)
+			}
+		}
+
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+		rc.stats.Misses++
+
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
+		if resp.StatusCode == http.StatusOK && (etag != "" || lastModified != "") {
+			rc.entries[key] = &cacheEntry{
+				resource:     req.resource(),
+				etag:         etag,
+				lastModified: lastModified,
+				resp:         resp.clone(),
+			}
+		} else {
+			delete(rc.entries, key)
+		}
+		return resp, nil
+	}
+}
+
+// clone returns a copy of r that shares nothing with the original.
+func (r *Response) clone() *Response {
+	return &Response{
+		StatusCode: r.StatusCode,
+		Header:     r.Header.Clone(),
+		Body:       append([]byte(nil), r.Body...),
+	}
+}
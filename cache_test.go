@@ -0,0 +1,219 @@
+package libecto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCacheTestServer serves a versioned tag and post list, honoring If-None-Match.
+func newCacheTestServer(t *testing.T, version *atomic.Int32, fullResponses *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"v` + string(rune('0'+version.Load())) + `"`
+		switch r.Method {
+		case "GET":
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			fullResponses.Add(1)
+			w.Header().Set("ETag", etag)
+			w.Write([]byte(`{"tags":[{"name":"News"}],"posts":[{"title":"Hello"}]}`))
+		default:
+			version.Add(1)
+			w.Write([]byte(`{"posts":[{"title":"Created"}]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResponseCache_Revalidates(t *testing.T) {
+	var version, full atomic.Int32
+	server := newCacheTestServer(t, &version, &full)
+	cache := NewResponseCache()
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(cache))
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.ListTags(0)
+		require.NoError(t, err)
+		require.Len(t, resp.Tags, 1)
+		assert.Equal(t, "News", resp.Tags[0].Name)
+	}
+
+	assert.Equal(t, int32(1), full.Load())
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 1, Entries: 1}, cache.Stats())
+}
+
+func TestResponseCache_InvalidatedByMutation(t *testing.T) {
+	var version, full atomic.Int32
+	server := newCacheTestServer(t, &version, &full)
+	cache := NewResponseCache()
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(cache))
+
+	_, err := client.ListPosts("", 0)
+	require.NoError(t, err)
+	_, err = client.ListTags(0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cache.Stats().Entries)
+
+	_, err = client.CreatePost(&Post{Title: "New"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, cache.Stats().Entries, "posts entry should be dropped, tags kept")
+
+	_, err = client.ListPosts("", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), full.Load())
+}
+
+func TestResponseCache_NotModifiedWithoutEntry(t *testing.T) {
+	var version, full atomic.Int32
+	server := newCacheTestServer(t, &version, &full)
+	cache := NewResponseCache()
+	conditional := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("If-None-Match", `"v0"`)
+			return next(ctx, req)
+		}
+	}
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(cache), WithMiddleware(conditional))
+
+	resp, err := client.ListTags(0)
+	require.NoError(t, err)
+	require.Len(t, resp.Tags, 1)
+	assert.Equal(t, int32(1), full.Load())
+	assert.Equal(t, CacheStats{Misses: 1, Entries: 1}, cache.Stats())
+}
+
+func TestResponseCache_InvalidatedInFlight(t *testing.T) {
+	var full atomic.Int32
+	cache := NewResponseCache()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v0"` {
+			// A concurrent write drops the entry before the 304 arrives.
+			cache.Invalidate("tags")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", `"v0"`)
+		w.Write([]byte(`{"tags":[{"name":"News"}]}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(cache))
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.ListTags(0)
+		require.NoError(t, err)
+		require.Len(t, resp.Tags, 1)
+	}
+	assert.Equal(t, int32(2), full.Load())
+	assert.Equal(t, int64(0), cache.Stats().Hits)
+}
+
+func TestResponseCache_UnconditionalNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(NewResponseCache()))
+
+	_, err := client.ListTags(0)
+	assert.ErrorContains(t, err, "304 Not Modified returned for an unconditional request")
+}
+
+func TestResponseCache_LastModified(t *testing.T) {
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	var conditional int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(`{"settings":[{"key":"title","value":"Blog"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(NewResponseCache()))
+	for i := 0; i < 2; i++ {
+		resp, err := client.GetSettings()
+		require.NoError(t, err)
+		assert.Equal(t, "Blog", resp.Settings[0].Value)
+	}
+	assert.Equal(t, 1, conditional)
+}
+
+func TestResponseCache_SkipsUncacheable(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		if r.URL.Path == "/ghost/api/admin/tags/" {
+			w.Write([]byte(`{"tags":[]}`))
+			return
+		}
+		w.Header().Set("ETag", `"x"`)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cache := NewResponseCache()
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(cache))
+	for i := 0; i < 2; i++ {
+		_, err := client.ListTags(0)
+		require.NoError(t, err)
+		_, err = client.GetSite()
+		require.Error(t, err)
+	}
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, 0, cache.Stats().Entries)
+}
+
+func TestResponseCache_ClearAndInvalidate(t *testing.T) {
+	var version, full atomic.Int32
+	server := newCacheTestServer(t, &version, &full)
+	cache := NewResponseCache()
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(cache))
+
+	_, err := client.ListPosts("", 0)
+	require.NoError(t, err)
+	_, err = client.ListTags(0)
+	require.NoError(t, err)
+
+	cache.Invalidate("tags")
+	assert.Equal(t, 1, cache.Stats().Entries)
+	cache.Clear()
+	assert.Equal(t, 0, cache.Stats().Entries)
+}
+
+func TestResponseCache_ReturnsCopies(t *testing.T) {
+	var version, full atomic.Int32
+	server := newCacheTestServer(t, &version, &full)
+
+	corrupted := false
+	corrupt := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := next(ctx, req)
+			if err == nil && !corrupted {
+				copy(resp.Body, "XXXXXXXXXX")
+				corrupted = true
+			}
+			return resp, err
+		}
+	}
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(NewResponseCache()), WithMiddleware(corrupt))
+
+	_, err := client.ListTags(0)
+	assert.Error(t, err, "middleware corrupted the body")
+	resp, err := client.ListTags(0)
+	require.NoError(t, err)
+	assert.Equal(t, "News", resp.Tags[0].Name)
+	assert.Equal(t, int32(1), full.Load(), "cached copy must survive middleware mutation")
+}
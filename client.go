@@ -1,23 +1,51 @@
 package libecto
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"log/slog"
 	"net/http"
-	"os"
-	"path/filepath"
+	"net/url"
 	"strings"
+	"sync"
+
+	"github.com/visionik/libecto/nql"
 )
 
 // Client is a Ghost Admin API client.
 // It handles authentication and provides methods for all Ghost Admin API endpoints.
+//
+// Every endpoint method has a Context variant (e.g. ListPostsContext) that
+// accepts a context.Context for cancellation and deadlines. The plain methods
+// are equivalent to calling the Context variant with context.Background().
 type Client struct {
 	baseURL    string
-	apiKey     string
+	tokens     TokenSource
 	httpClient *http.Client
+	retry      RetryPolicy
+	limiter    *RateLimiter
+	middleware []Middleware
+	handler    Handler
+	logger     *slog.Logger
+	logBody    bool
+	// content marks a Content API client, which authenticates with the
+	// contentKey query parameter instead of an Admin API token.
+	content    bool
+	contentKey string
+
+	acceptVersion  string
+	onDeprecation  func(ctx context.Context, d Deprecation)
+	versionMu      sync.Mutex
+	contentVersion string
+	siteVersion    *Version
+
+	cache  *ResponseCache
+	dryRun *Plan
+
+	maxUploadSize int64
 }
 
 // ClientOption is a function that configures a Client.
@@ -32,20 +60,30 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
+// WithTokenSource sets the source of Admin API tokens, replacing the
+// caching source built from the API key passed to NewClient.
+func WithTokenSource(ts TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokens = ts
+	}
+}
+
 // NewClient creates a new Ghost Admin API client.
 // The url parameter is the Ghost site URL (e.g., "https://mysite.ghost.io").
-// The apiKey parameter is the Admin API key in "id:secret" format.
+// The apiKey parameter is the Admin API key in "id:secret" format; it may be
+// empty when a TokenSource is supplied with WithTokenSource.
 // Optional ClientOption functions can be passed to customize the client.
 func NewClient(url, apiKey string, opts ...ClientOption) *Client {
 	url = strings.TrimSuffix(url, "/")
 	c := &Client{
 		baseURL:    url + "/ghost/api/admin",
-		apiKey:     apiKey,
+		tokens:     NewTokenSource(apiKey),
 		httpClient: &http.Client{},
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.build()
 	return c
 }
 
@@ -55,110 +93,231 @@ func (c *Client) BaseURL() string {
 	return c.baseURL
 }
 
-func (c *Client) request(method, path string, body interface{}) (*http.Response, error) {
-	token, err := GenerateToken(c.apiKey)
-	if err != nil {
-		return nil, fmt.Errorf("generating token: %w", err)
-	}
+// errToken marks failures to produce an authentication token, which are never retried.
+var errToken = errors.New("generating token")
 
-	var bodyReader io.Reader
-	if body != nil {
-		data, err := json.Marshal(body)
+// request sends an authenticated request to path, relative to the base URL.
+// It waits for the rate limiter, if any, and is bound to ctx, so cancelling
+// ctx aborts both the wait and the HTTP round trip.
+func (c *Client) request(ctx context.Context, method, path string, header http.Header, contentType string, body io.Reader) (*http.Response, error) {
+	if c.limiter != nil {
+		wait, err := c.limiter.Wait(ctx)
 		if err != nil {
 			return nil, err
 		}
-		bodyReader = bytes.NewReader(data)
+		c.logRateLimitWait(ctx, method, path, wait)
+	}
+
+	target := c.baseURL + path
+	var auth string
+	switch {
+	case c.content && c.contentKey == "":
+		return nil, fmt.Errorf("%w: Content API key is empty", errToken)
+	case c.content:
+		target = withQuery(target, url.Values{"key": {c.contentKey}})
+	case c.tokens == nil:
+		return nil, fmt.Errorf("%w: no API key or token source", errToken)
+	default:
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errToken, err)
+		}
+		auth = "Ghost " + token
 	}
 
-	url := c.baseURL + path
-	req, err := http.NewRequest(method, url, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Ghost "+token)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	if c.acceptVersion != "" && req.Header.Get("Accept-Version") == "" {
+		req.Header.Set("Accept-Version", c.acceptVersion)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	return c.httpClient.Do(req)
 }
 
-func (c *Client) do(method, path string, body, result interface{}) error {
-	resp, err := c.request(method, path, body)
+// send is the innermost Handler: it encodes the request body and sends it with retries.
+func (c *Client) send(ctx context.Context, req *Request) (*Response, error) {
+	body, err := encodeBody(req.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	resp, err := c.sendWithRetry(ctx, req.Method, req.Path, req.Header, body)
+	if err != nil {
+		return nil, err
+	}
+	c.observeVersion(ctx, req, resp)
+	return resp, nil
+}
+
+// do runs an API call through the middleware chain and decodes the JSON
+// response into result. Error status codes are returned as a *ResponseError.
+func (c *Client) do(ctx context.Context, method, path string, body, result interface{}) error {
+	req := &Request{Method: method, Path: path, Header: http.Header{}, Body: body}
+	resp, err := c.handler(ctx, req)
 	if err != nil {
 		return err
 	}
 
 	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if json.Unmarshal(respBody, &errResp) == nil && len(errResp.Errors) > 0 {
-			msg := errResp.Errors[0].Message
-			if errResp.Errors[0].Context != "" {
-				msg += ": " + errResp.Errors[0].Context
-			}
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, msg)
-		}
-		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		return newResponseError(resp.StatusCode, req.Method, req.Path, resp.Body)
 	}
 
 	if result != nil {
-		return json.Unmarshal(respBody, result)
+		return json.Unmarshal(resp.Body, result)
 	}
 	return nil
 }
 
+// IsObjectID reports whether s has the form of a Ghost resource ID: 24
+// hexadecimal characters. Ghost rejects any other value in an ID path with
+// a 422 validation error rather than a 404.
+func IsObjectID(s string) bool {
+	if len(s) != 24 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
 // Posts
 
 // ListPosts returns a list of posts from the Ghost site.
 // The status parameter can be "draft", "published", "scheduled", or "all" (empty string also returns all).
 // The limit parameter controls the number of results (0 for default).
 func (c *Client) ListPosts(status string, limit int) (*PostsResponse, error) {
-	path := "/posts/?formats=html"
+	return c.ListPostsContext(context.Background(), status, limit)
+}
+
+// ListPostsContext is like ListPosts but uses ctx for the request.
+func (c *Client) ListPostsContext(ctx context.Context, status string, limit int) (*PostsResponse, error) {
+	opts := &ListOptions{Limit: limit}
 	if status != "" && status != "all" {
-		path += "&filter=status:" + status
-	}
-	if limit > 0 {
-		path += fmt.Sprintf("&limit=%d", limit)
+		opts.Filter = nql.Eq("status", status).String()
 	}
+	return c.ListPostsWithOptions(ctx, opts)
+}
 
+// ListPostsWithOptions returns posts matching opts. HTML is returned unless
+// opts.Formats says otherwise; a nil opts lists the first page.
+func (c *Client) ListPostsWithOptions(ctx context.Context, opts *ListOptions) (*PostsResponse, error) {
 	var resp PostsResponse
-	if err := c.do("GET", path, nil, &resp); err != nil {
+	if err := c.do(ctx, "GET", withQuery("/posts/", contentQuery(opts)), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
 // GetPost returns a single post by ID or slug.
-// It first tries to find by ID, then falls back to slug lookup.
+// Values that look like an ID (see IsObjectID) are looked up by ID first and
+// by slug if Ghost reports that no post has that ID; anything else is looked
+// up by slug directly. Other errors are returned as is.
 func (c *Client) GetPost(idOrSlug string) (*Post, error) {
-	var resp PostsResponse
-	err := c.do("GET", "/posts/"+idOrSlug+"/?formats=html", nil, &resp)
+	return c.GetPostContext(context.Background(), idOrSlug)
+}
+
+// GetPostContext is like GetPost but uses ctx for the requests.
+func (c *Client) GetPostContext(ctx context.Context, idOrSlug string) (*Post, error) {
+	return c.GetPostWithOptions(ctx, idOrSlug, nil)
+}
+
+// GetPostWithOptions is like GetPostContext but requests the related data,
+// fields and formats in opts. Unless opts.Formats says otherwise, HTML is
+// returned along with the Lexical or Mobiledoc content, so the post can be
+// passed back to UpdatePost without Ghost re-importing its HTML.
+func (c *Client) GetPostWithOptions(ctx context.Context, idOrSlug string, opts *GetOptions) (*Post, error) {
+	if !IsObjectID(idOrSlug) {
+		return c.getPost(ctx, "/posts/slug/"+url.PathEscape(idOrSlug)+"/", idOrSlug, opts)
+	}
+	post, err := c.getPost(ctx, "/posts/"+idOrSlug+"/", idOrSlug, opts)
+	if IsNotFound(err) {
+		return c.getPost(ctx, "/posts/slug/"+idOrSlug+"/", idOrSlug, opts)
+	}
+	return post, err
+}
+
+// GetPostByID returns the post with the given ID.
+func (c *Client) GetPostByID(id string) (*Post, error) {
+	return c.GetPostByIDContext(context.Background(), id)
+}
+
+// GetPostByIDContext is like GetPostByID but uses ctx for the request.
+func (c *Client) GetPostByIDContext(ctx context.Context, id string) (*Post, error) {
+	return c.getPost(ctx, "/posts/"+url.PathEscape(id)+"/", id, nil)
+}
+
+// GetPostBySlug returns the post with the given slug.
+func (c *Client) GetPostBySlug(slug string) (*Post, error) {
+	return c.GetPostBySlugContext(context.Background(), slug)
+}
+
+// GetPostBySlugContext is like GetPostBySlug but uses ctx for the request.
+func (c *Client) GetPostBySlugContext(ctx context.Context, slug string) (*Post, error) {
+	return c.getPost(ctx, "/posts/slug/"+url.PathEscape(slug)+"/", slug, nil)
+}
+
+// GetPostByUUID returns the post with the given UUID.
+// Ghost has no UUID endpoint, so this filters the posts list by uuid.
+func (c *Client) GetPostByUUID(uuid string) (*Post, error) {
+	return c.GetPostByUUIDContext(context.Background(), uuid)
+}
+
+// GetPostByUUIDContext is like GetPostByUUID but uses ctx for the request.
+func (c *Client) GetPostByUUIDContext(ctx context.Context, uuid string) (*Post, error) {
+	resp, err := c.ListPostsWithOptions(ctx, &ListOptions{
+		Filter:  nql.Eq("uuid", uuid).String(),
+		Formats: editFormats,
+		Limit:   1,
+	})
 	if err != nil {
-		// Try by slug
-		err = c.do("GET", "/posts/slug/"+idOrSlug+"/?formats=html", nil, &resp)
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
 	if len(resp.Posts) == 0 {
-		return nil, fmt.Errorf("post not found: %s", idOrSlug)
+		return nil, notFoundError("GET", "/posts/", "Post not found.")
+	}
+	return &resp.Posts[0], nil
+}
+
+// getPost fetches a single post from path; ref identifies it in errors.
+func (c *Client) getPost(ctx context.Context, path, ref string, opts *GetOptions) (*Post, error) {
+	var resp PostsResponse
+	if err := c.do(ctx, "GET", withQuery(path, editQuery(opts)), nil, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Posts) == 0 {
+		return nil, fmt.Errorf("post not found: %s", ref)
 	}
 	return &resp.Posts[0], nil
 }
 
 // CreatePost creates a new post with the given data.
-// At minimum, the post should have a Title set.
+// At minimum, the post should have a Title set. Lexical or Mobiledoc content
+// is stored as is; HTML is converted by Ghost only when neither is set.
 func (c *Client) CreatePost(post *Post) (*Post, error) {
-	body := map[string][]Post{"posts": {*post}}
+	return c.CreatePostContext(context.Background(), post)
+}
+
+// CreatePostContext is like CreatePost but uses ctx for the request.
+func (c *Client) CreatePostContext(ctx context.Context, post *Post) (*Post, error) {
+	body := map[string][]Post{"posts": {post.writable()}}
 	var resp PostsResponse
-	if err := c.do("POST", "/posts/?source=html&formats=html", body, &resp); err != nil {
+	if err := c.do(ctx, "POST", writePath("/posts/", post.HTML, post.Lexical, post.Mobiledoc), body, &resp); err != nil {
 		return nil, err
 	}
 	if len(resp.Posts) == 0 {
@@ -169,10 +328,24 @@ func (c *Client) CreatePost(post *Post) (*Post, error) {
 
 // UpdatePost updates an existing post by ID.
 // The post.UpdatedAt field should be set to the current updated_at value for conflict detection.
+// As with CreatePost, HTML is only converted when the post has no Lexical or
+// Mobiledoc content, so clear both to replace a fetched post's content with HTML.
 func (c *Client) UpdatePost(id string, post *Post) (*Post, error) {
-	body := map[string][]Post{"posts": {*post}}
+	return c.UpdatePostContext(context.Background(), id, post)
+}
+
+// UpdatePostContext is like UpdatePost but uses ctx for the request.
+func (c *Client) UpdatePostContext(ctx context.Context, id string, post *Post) (*Post, error) {
+	return c.updatePost(ctx, id, post, nil)
+}
+
+// updatePost sends post as an update of the post with the given ID, adding
+// extra to the query.
+func (c *Client) updatePost(ctx context.Context, id string, post *Post, extra url.Values) (*Post, error) {
+	body := map[string][]Post{"posts": {post.writable()}}
+	path := withQuery(writePath("/posts/"+url.PathEscape(id)+"/", post.HTML, post.Lexical, post.Mobiledoc), extra)
 	var resp PostsResponse
-	if err := c.do("PUT", "/posts/"+id+"/?source=html&formats=html", body, &resp); err != nil {
+	if err := c.do(ctx, "PUT", path, body, &resp); err != nil {
 		return nil, err
 	}
 	if len(resp.Posts) == 0 {
@@ -183,30 +356,57 @@ func (c *Client) UpdatePost(id string, post *Post) (*Post, error) {
 
 // DeletePost permanently deletes a post by ID.
 func (c *Client) DeletePost(id string) error {
-	return c.do("DELETE", "/posts/"+id+"/", nil, nil)
+	return c.DeletePostContext(context.Background(), id)
+}
+
+// DeletePostContext is like DeletePost but uses ctx for the request.
+func (c *Client) DeletePostContext(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/posts/"+url.PathEscape(id)+"/", nil, nil)
 }
 
 // PublishPost publishes a draft post by ID or slug.
 // It first retrieves the post to get the current updated_at for conflict detection.
 func (c *Client) PublishPost(idOrSlug string) (*Post, error) {
-	existing, err := c.GetPost(idOrSlug)
+	return c.PublishPostContext(context.Background(), idOrSlug)
+}
+
+// PublishPostContext is like PublishPost but uses ctx for the requests.
+func (c *Client) PublishPostContext(ctx context.Context, idOrSlug string) (*Post, error) {
+	return c.PublishPostWithOptions(ctx, idOrSlug, nil)
+}
+
+// PublishPostWithOptions is like PublishPostContext but can also send the
+// post as a newsletter email, or only as an email, as set in opts. When an
+// email is sent, the returned post's Email describes it; Ghost sends it in
+// the background, so poll GetEmail with its ID to track delivery.
+func (c *Client) PublishPostWithOptions(ctx context.Context, idOrSlug string, opts *PublishOptions) (*Post, error) {
+	if opts != nil && opts.Newsletter == "" && (opts.EmailOnly || opts.EmailSegment != "") {
+		return nil, fmt.Errorf("sending an email requires a newsletter")
+	}
+	existing, err := c.GetPostContext(ctx, idOrSlug)
 	if err != nil {
 		return nil, err
 	}
-	return c.UpdatePost(existing.ID, &Post{
+	return c.updatePost(ctx, existing.ID, &Post{
 		UpdatedAt: existing.UpdatedAt,
 		Status:    "published",
-	})
+		EmailOnly: opts != nil && opts.EmailOnly,
+	}, opts.values())
 }
 
 // UnpublishPost unpublishes a post (sets to draft) by ID or slug.
 // It first retrieves the post to get the current updated_at for conflict detection.
 func (c *Client) UnpublishPost(idOrSlug string) (*Post, error) {
-	existing, err := c.GetPost(idOrSlug)
+	return c.UnpublishPostContext(context.Background(), idOrSlug)
+}
+
+// UnpublishPostContext is like UnpublishPost but uses ctx for the requests.
+func (c *Client) UnpublishPostContext(ctx context.Context, idOrSlug string) (*Post, error) {
+	existing, err := c.GetPostContext(ctx, idOrSlug)
 	if err != nil {
 		return nil, err
 	}
-	return c.UpdatePost(existing.ID, &Post{
+	return c.UpdatePostContext(ctx, existing.ID, &Post{
 		UpdatedAt: existing.UpdatedAt,
 		Status:    "draft",
 	})
@@ -215,11 +415,16 @@ func (c *Client) UnpublishPost(idOrSlug string) (*Post, error) {
 // SchedulePost schedules a post for publication at a specific time.
 // The publishAt parameter should be an ISO8601 timestamp (e.g., "2025-01-15T12:00:00Z").
 func (c *Client) SchedulePost(idOrSlug, publishAt string) (*Post, error) {
-	existing, err := c.GetPost(idOrSlug)
+	return c.SchedulePostContext(context.Background(), idOrSlug, publishAt)
+}
+
+// SchedulePostContext is like SchedulePost but uses ctx for the requests.
+func (c *Client) SchedulePostContext(ctx context.Context, idOrSlug, publishAt string) (*Post, error) {
+	existing, err := c.GetPostContext(ctx, idOrSlug)
 	if err != nil {
 		return nil, err
 	}
-	return c.UpdatePost(existing.ID, &Post{
+	return c.UpdatePostContext(ctx, existing.ID, &Post{
 		UpdatedAt:   existing.UpdatedAt,
 		Status:      "scheduled",
 		PublishedAt: publishAt,
@@ -232,44 +437,122 @@ func (c *Client) SchedulePost(idOrSlug, publishAt string) (*Post, error) {
 // The status parameter can be "draft", "published", or "all".
 // The limit parameter controls the number of results (0 for default).
 func (c *Client) ListPages(status string, limit int) (*PagesResponse, error) {
-	path := "/pages/?formats=html"
+	return c.ListPagesContext(context.Background(), status, limit)
+}
+
+// ListPagesContext is like ListPages but uses ctx for the request.
+func (c *Client) ListPagesContext(ctx context.Context, status string, limit int) (*PagesResponse, error) {
+	opts := &ListOptions{Limit: limit}
 	if status != "" && status != "all" {
-		path += "&filter=status:" + status
-	}
-	if limit > 0 {
-		path += fmt.Sprintf("&limit=%d", limit)
+		opts.Filter = nql.Eq("status", status).String()
 	}
+	return c.ListPagesWithOptions(ctx, opts)
+}
 
+// ListPagesWithOptions returns pages matching opts. HTML is returned unless
+// opts.Formats says otherwise; a nil opts lists the first page.
+func (c *Client) ListPagesWithOptions(ctx context.Context, opts *ListOptions) (*PagesResponse, error) {
 	var resp PagesResponse
-	if err := c.do("GET", path, nil, &resp); err != nil {
+	if err := c.do(ctx, "GET", withQuery("/pages/", contentQuery(opts)), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
 // GetPage returns a single page by ID or slug.
-// It first tries to find by ID, then falls back to slug lookup.
+// Values that look like an ID (see IsObjectID) are looked up by ID first and
+// by slug if Ghost reports that no page has that ID; anything else is looked
+// up by slug directly. Other errors are returned as is.
 func (c *Client) GetPage(idOrSlug string) (*Page, error) {
-	var resp PagesResponse
-	err := c.do("GET", "/pages/"+idOrSlug+"/?formats=html", nil, &resp)
+	return c.GetPageContext(context.Background(), idOrSlug)
+}
+
+// GetPageContext is like GetPage but uses ctx for the requests.
+func (c *Client) GetPageContext(ctx context.Context, idOrSlug string) (*Page, error) {
+	return c.GetPageWithOptions(ctx, idOrSlug, nil)
+}
+
+// GetPageWithOptions is like GetPageContext but requests the related data,
+// fields and formats in opts. Unless opts.Formats says otherwise, HTML is
+// returned along with the Lexical or Mobiledoc content, so the page can be
+// passed back to UpdatePage without Ghost re-importing its HTML.
+func (c *Client) GetPageWithOptions(ctx context.Context, idOrSlug string, opts *GetOptions) (*Page, error) {
+	if !IsObjectID(idOrSlug) {
+		return c.getPage(ctx, "/pages/slug/"+url.PathEscape(idOrSlug)+"/", idOrSlug, opts)
+	}
+	page, err := c.getPage(ctx, "/pages/"+idOrSlug+"/", idOrSlug, opts)
+	if IsNotFound(err) {
+		return c.getPage(ctx, "/pages/slug/"+idOrSlug+"/", idOrSlug, opts)
+	}
+	return page, err
+}
+
+// GetPageByID returns the page with the given ID.
+func (c *Client) GetPageByID(id string) (*Page, error) {
+	return c.GetPageByIDContext(context.Background(), id)
+}
+
+// GetPageByIDContext is like GetPageByID but uses ctx for the request.
+func (c *Client) GetPageByIDContext(ctx context.Context, id string) (*Page, error) {
+	return c.getPage(ctx, "/pages/"+url.PathEscape(id)+"/", id, nil)
+}
+
+// GetPageBySlug returns the page with the given slug.
+func (c *Client) GetPageBySlug(slug string) (*Page, error) {
+	return c.GetPageBySlugContext(context.Background(), slug)
+}
+
+// GetPageBySlugContext is like GetPageBySlug but uses ctx for the request.
+func (c *Client) GetPageBySlugContext(ctx context.Context, slug string) (*Page, error) {
+	return c.getPage(ctx, "/pages/slug/"+url.PathEscape(slug)+"/", slug, nil)
+}
+
+// GetPageByUUID returns the page with the given UUID.
+// Ghost has no UUID endpoint, so this filters the pages list by uuid.
+func (c *Client) GetPageByUUID(uuid string) (*Page, error) {
+	return c.GetPageByUUIDContext(context.Background(), uuid)
+}
+
+// GetPageByUUIDContext is like GetPageByUUID but uses ctx for the request.
+func (c *Client) GetPageByUUIDContext(ctx context.Context, uuid string) (*Page, error) {
+	resp, err := c.ListPagesWithOptions(ctx, &ListOptions{
+		Filter:  nql.Eq("uuid", uuid).String(),
+		Formats: editFormats,
+		Limit:   1,
+	})
 	if err != nil {
-		err = c.do("GET", "/pages/slug/"+idOrSlug+"/?formats=html", nil, &resp)
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
+	}
+	if len(resp.Pages) == 0 {
+		return nil, notFoundError("GET", "/pages/", "Page not found.")
+	}
+	return &resp.Pages[0], nil
+}
+
+// getPage fetches a single page from path; ref identifies it in errors.
+func (c *Client) getPage(ctx context.Context, path, ref string, opts *GetOptions) (*Page, error) {
+	var resp PagesResponse
+	if err := c.do(ctx, "GET", withQuery(path, editQuery(opts)), nil, &resp); err != nil {
+		return nil, err
 	}
 	if len(resp.Pages) == 0 {
-		return nil, fmt.Errorf("page not found: %s", idOrSlug)
+		return nil, fmt.Errorf("page not found: %s", ref)
 	}
 	return &resp.Pages[0], nil
 }
 
 // CreatePage creates a new page with the given data.
-// At minimum, the page should have a Title set.
+// At minimum, the page should have a Title set. As with CreatePost, HTML is
+// converted by Ghost only when Lexical and Mobiledoc are empty.
 func (c *Client) CreatePage(page *Page) (*Page, error) {
-	body := map[string][]Page{"pages": {*page}}
+	return c.CreatePageContext(context.Background(), page)
+}
+
+// CreatePageContext is like CreatePage but uses ctx for the request.
+func (c *Client) CreatePageContext(ctx context.Context, page *Page) (*Page, error) {
+	body := map[string][]Page{"pages": {page.writable()}}
 	var resp PagesResponse
-	if err := c.do("POST", "/pages/?formats=html", body, &resp); err != nil {
+	if err := c.do(ctx, "POST", writePath("/pages/", page.HTML, page.Lexical, page.Mobiledoc), body, &resp); err != nil {
 		return nil, err
 	}
 	if len(resp.Pages) == 0 {
@@ -281,9 +564,14 @@ func (c *Client) CreatePage(page *Page) (*Page, error) {
 // UpdatePage updates an existing page by ID.
 // The page.UpdatedAt field should be set to the current updated_at value for conflict detection.
 func (c *Client) UpdatePage(id string, page *Page) (*Page, error) {
-	body := map[string][]Page{"pages": {*page}}
+	return c.UpdatePageContext(context.Background(), id, page)
+}
+
+// UpdatePageContext is like UpdatePage but uses ctx for the request.
+func (c *Client) UpdatePageContext(ctx context.Context, id string, page *Page) (*Page, error) {
+	body := map[string][]Page{"pages": {page.writable()}}
 	var resp PagesResponse
-	if err := c.do("PUT", "/pages/"+id+"/?formats=html", body, &resp); err != nil {
+	if err := c.do(ctx, "PUT", writePath("/pages/"+url.PathEscape(id)+"/", page.HTML, page.Lexical, page.Mobiledoc), body, &resp); err != nil {
 		return nil, err
 	}
 	if len(resp.Pages) == 0 {
@@ -294,17 +582,27 @@ func (c *Client) UpdatePage(id string, page *Page) (*Page, error) {
 
 // DeletePage permanently deletes a page by ID.
 func (c *Client) DeletePage(id string) error {
-	return c.do("DELETE", "/pages/"+id+"/", nil, nil)
+	return c.DeletePageContext(context.Background(), id)
+}
+
+// DeletePageContext is like DeletePage but uses ctx for the request.
+func (c *Client) DeletePageContext(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/pages/"+url.PathEscape(id)+"/", nil, nil)
 }
 
 // PublishPage publishes a draft page by ID or slug.
 // It first retrieves the page to get the current updated_at for conflict detection.
 func (c *Client) PublishPage(idOrSlug string) (*Page, error) {
-	existing, err := c.GetPage(idOrSlug)
+	return c.PublishPageContext(context.Background(), idOrSlug)
+}
+
+// PublishPageContext is like PublishPage but uses ctx for the requests.
+func (c *Client) PublishPageContext(ctx context.Context, idOrSlug string) (*Page, error) {
+	existing, err := c.GetPageContext(ctx, idOrSlug)
 	if err != nil {
 		return nil, err
 	}
-	return c.UpdatePage(existing.ID, &Page{
+	return c.UpdatePageContext(ctx, existing.ID, &Page{
 		UpdatedAt: existing.UpdatedAt,
 		Status:    "published",
 	})
@@ -316,31 +614,76 @@ func (c *Client) PublishPage(idOrSlug string) (*Page, error) {
 // The limit parameter controls the number of results (0 for default).
 // Results include post counts for each tag.
 func (c *Client) ListTags(limit int) (*TagsResponse, error) {
-	path := "/tags/?include=count.posts"
-	if limit > 0 {
-		path += fmt.Sprintf("&limit=%d", limit)
-	}
+	return c.ListTagsContext(context.Background(), limit)
+}
 
+// ListTagsContext is like ListTags but uses ctx for the request.
+func (c *Client) ListTagsContext(ctx context.Context, limit int) (*TagsResponse, error) {
+	return c.ListTagsWithOptions(ctx, &ListOptions{Include: []string{IncludeCountPosts}, Limit: limit})
+}
+
+// ListTagsWithOptions returns tags matching opts. A nil opts lists the first page.
+func (c *Client) ListTagsWithOptions(ctx context.Context, opts *ListOptions) (*TagsResponse, error) {
 	var resp TagsResponse
-	if err := c.do("GET", path, nil, &resp); err != nil {
+	if err := c.do(ctx, "GET", withQuery("/tags/", opts.values()), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
 // GetTag returns a single tag by ID or slug.
-// It first tries to find by ID, then falls back to slug lookup.
+// Values that look like an ID (see IsObjectID) are looked up by ID first and
+// by slug if Ghost reports that no tag has that ID; anything else is looked
+// up by slug directly. Other errors are returned as is.
 func (c *Client) GetTag(idOrSlug string) (*Tag, error) {
+	return c.GetTagContext(context.Background(), idOrSlug)
+}
+
+// GetTagContext is like GetTag but uses ctx for the requests.
+func (c *Client) GetTagContext(ctx context.Context, idOrSlug string) (*Tag, error) {
+	return c.GetTagWithOptions(ctx, idOrSlug, nil)
+}
+
+// GetTagWithOptions is like GetTagContext but requests the related data and fields in opts.
+func (c *Client) GetTagWithOptions(ctx context.Context, idOrSlug string, opts *GetOptions) (*Tag, error) {
+	if !IsObjectID(idOrSlug) {
+		return c.getTag(ctx, "/tags/slug/"+url.PathEscape(idOrSlug)+"/", idOrSlug, opts)
+	}
+	tag, err := c.getTag(ctx, "/tags/"+idOrSlug+"/", idOrSlug, opts)
+	if IsNotFound(err) {
+		return c.getTag(ctx, "/tags/slug/"+idOrSlug+"/", idOrSlug, opts)
+	}
+	return tag, err
+}
+
+// GetTagByID returns the tag with the given ID.
+func (c *Client) GetTagByID(id string) (*Tag, error) {
+	return c.GetTagByIDContext(context.Background(), id)
+}
+
+// GetTagByIDContext is like GetTagByID but uses ctx for the request.
+func (c *Client) GetTagByIDContext(ctx context.Context, id string) (*Tag, error) {
+	return c.getTag(ctx, "/tags/"+url.PathEscape(id)+"/", id, nil)
+}
+
+// GetTagBySlug returns the tag with the given slug.
+func (c *Client) GetTagBySlug(slug string) (*Tag, error) {
+	return c.GetTagBySlugContext(context.Background(), slug)
+}
+
+// GetTagBySlugContext is like GetTagBySlug but uses ctx for the request.
+func (c *Client) GetTagBySlugContext(ctx context.Context, slug string) (*Tag, error) {
+	return c.getTag(ctx, "/tags/slug/"+url.PathEscape(slug)+"/", slug, nil)
+}
+
+// getTag fetches a single tag from path; ref identifies it in errors.
+func (c *Client) getTag(ctx context.Context, path, ref string, opts *GetOptions) (*Tag, error) {
 	var resp TagsResponse
-	err := c.do("GET", "/tags/"+idOrSlug+"/", nil, &resp)
-	if err != nil {
-		err = c.do("GET", "/tags/slug/"+idOrSlug+"/", nil, &resp)
-		if err != nil {
-			return nil, err
-		}
+	if err := c.do(ctx, "GET", withQuery(path, opts.values()), nil, &resp); err != nil {
+		return nil, err
 	}
 	if len(resp.Tags) == 0 {
-		return nil, fmt.Errorf("tag not found: %s", idOrSlug)
+		return nil, fmt.Errorf("tag not found: %s", ref)
 	}
 	return &resp.Tags[0], nil
 }
@@ -348,9 +691,14 @@ func (c *Client) GetTag(idOrSlug string) (*Tag, error) {
 // CreateTag creates a new tag with the given data.
 // At minimum, the tag should have a Name set.
 func (c *Client) CreateTag(tag *Tag) (*Tag, error) {
+	return c.CreateTagContext(context.Background(), tag)
+}
+
+// CreateTagContext is like CreateTag but uses ctx for the request.
+func (c *Client) CreateTagContext(ctx context.Context, tag *Tag) (*Tag, error) {
 	body := map[string][]Tag{"tags": {*tag}}
 	var resp TagsResponse
-	if err := c.do("POST", "/tags/", body, &resp); err != nil {
+	if err := c.do(ctx, "POST", "/tags/", body, &resp); err != nil {
 		return nil, err
 	}
 	if len(resp.Tags) == 0 {
@@ -361,9 +709,14 @@ func (c *Client) CreateTag(tag *Tag) (*Tag, error) {
 
 // UpdateTag updates an existing tag by ID.
 func (c *Client) UpdateTag(id string, tag *Tag) (*Tag, error) {
+	return c.UpdateTagContext(context.Background(), id, tag)
+}
+
+// UpdateTagContext is like UpdateTag but uses ctx for the request.
+func (c *Client) UpdateTagContext(ctx context.Context, id string, tag *Tag) (*Tag, error) {
 	body := map[string][]Tag{"tags": {*tag}}
 	var resp TagsResponse
-	if err := c.do("PUT", "/tags/"+id+"/", body, &resp); err != nil {
+	if err := c.do(ctx, "PUT", "/tags/"+url.PathEscape(id)+"/", body, &resp); err != nil {
 		return nil, err
 	}
 	if len(resp.Tags) == 0 {
@@ -375,33 +728,88 @@ func (c *Client) UpdateTag(id string, tag *Tag) (*Tag, error) {
 // DeleteTag permanently deletes a tag by ID.
 // This removes the tag from all posts that use it.
 func (c *Client) DeleteTag(id string) error {
-	return c.do("DELETE", "/tags/"+id+"/", nil, nil)
+	return c.DeleteTagContext(context.Background(), id)
+}
+
+// DeleteTagContext is like DeleteTag but uses ctx for the request.
+func (c *Client) DeleteTagContext(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/tags/"+url.PathEscape(id)+"/", nil, nil)
 }
 
 // Users
 
 // ListUsers returns a list of all users on the Ghost site.
 func (c *Client) ListUsers() (*UsersResponse, error) {
+	return c.ListUsersContext(context.Background())
+}
+
+// ListUsersContext is like ListUsers but uses ctx for the request.
+func (c *Client) ListUsersContext(ctx context.Context) (*UsersResponse, error) {
+	return c.ListUsersWithOptions(ctx, nil)
+}
+
+// ListUsersWithOptions returns users matching opts. A nil opts lists the first page.
+func (c *Client) ListUsersWithOptions(ctx context.Context, opts *ListOptions) (*UsersResponse, error) {
 	var resp UsersResponse
-	if err := c.do("GET", "/users/", nil, &resp); err != nil {
+	if err := c.do(ctx, "GET", withQuery("/users/", opts.values()), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
 // GetUser returns a single user by ID or slug.
-// It first tries to find by ID, then falls back to slug lookup.
+// Values that look like an ID (see IsObjectID) are looked up by ID first and
+// by slug if Ghost reports that no user has that ID; anything else is looked
+// up by slug directly. Other errors are returned as is.
 func (c *Client) GetUser(idOrSlug string) (*Author, error) {
+	return c.GetUserContext(context.Background(), idOrSlug)
+}
+
+// GetUserContext is like GetUser but uses ctx for the requests.
+func (c *Client) GetUserContext(ctx context.Context, idOrSlug string) (*Author, error) {
+	return c.GetUserWithOptions(ctx, idOrSlug, nil)
+}
+
+// GetUserWithOptions is like GetUserContext but requests the related data and fields in opts.
+func (c *Client) GetUserWithOptions(ctx context.Context, idOrSlug string, opts *GetOptions) (*Author, error) {
+	if !IsObjectID(idOrSlug) {
+		return c.getUser(ctx, "/users/slug/"+url.PathEscape(idOrSlug)+"/", idOrSlug, opts)
+	}
+	user, err := c.getUser(ctx, "/users/"+idOrSlug+"/", idOrSlug, opts)
+	if IsNotFound(err) {
+		return c.getUser(ctx, "/users/slug/"+idOrSlug+"/", idOrSlug, opts)
+	}
+	return user, err
+}
+
+// GetUserByID returns the user with the given ID.
+func (c *Client) GetUserByID(id string) (*Author, error) {
+	return c.GetUserByIDContext(context.Background(), id)
+}
+
+// GetUserByIDContext is like GetUserByID but uses ctx for the request.
+func (c *Client) GetUserByIDContext(ctx context.Context, id string) (*Author, error) {
+	return c.getUser(ctx, "/users/"+url.PathEscape(id)+"/", id, nil)
+}
+
+// GetUserBySlug returns the user with the given slug.
+func (c *Client) GetUserBySlug(slug string) (*Author, error) {
+	return c.GetUserBySlugContext(context.Background(), slug)
+}
+
+// GetUserBySlugContext is like GetUserBySlug but uses ctx for the request.
+func (c *Client) GetUserBySlugContext(ctx context.Context, slug string) (*Author, error) {
+	return c.getUser(ctx, "/users/slug/"+url.PathEscape(slug)+"/", slug, nil)
+}
+
+// getUser fetches a single user from path; ref identifies it in errors.
+func (c *Client) getUser(ctx context.Context, path, ref string, opts *GetOptions) (*Author, error) {
 	var resp UsersResponse
-	err := c.do("GET", "/users/"+idOrSlug+"/", nil, &resp)
-	if err != nil {
-		err = c.do("GET", "/users/slug/"+idOrSlug+"/", nil, &resp)
-		if err != nil {
-			return nil, err
-		}
+	if err := c.do(ctx, "GET", withQuery(path, opts.values()), nil, &resp); err != nil {
+		return nil, err
 	}
 	if len(resp.Users) == 0 {
-		return nil, fmt.Errorf("user not found: %s", idOrSlug)
+		return nil, fmt.Errorf("user not found: %s", ref)
 	}
 	return &resp.Users[0], nil
 }
@@ -410,8 +818,13 @@ func (c *Client) GetUser(idOrSlug string) (*Author, error) {
 
 // GetSite returns information about the Ghost site including title, description, and version.
 func (c *Client) GetSite() (*Site, error) {
+	return c.GetSiteContext(context.Background())
+}
+
+// GetSiteContext is like GetSite but uses ctx for the request.
+func (c *Client) GetSiteContext(ctx context.Context) (*Site, error) {
 	var resp SiteResponse
-	if err := c.do("GET", "/site/", nil, &resp); err != nil {
+	if err := c.do(ctx, "GET", "/site/", nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Site, nil
@@ -421,8 +834,13 @@ func (c *Client) GetSite() (*Site, error) {
 
 // GetSettings returns the site settings as key-value pairs.
 func (c *Client) GetSettings() (*SettingsResponse, error) {
+	return c.GetSettingsContext(context.Background())
+}
+
+// GetSettingsContext is like GetSettings but uses ctx for the request.
+func (c *Client) GetSettingsContext(ctx context.Context) (*SettingsResponse, error) {
 	var resp SettingsResponse
-	if err := c.do("GET", "/settings/", nil, &resp); err != nil {
+	if err := c.do(ctx, "GET", "/settings/", nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -432,8 +850,18 @@ func (c *Client) GetSettings() (*SettingsResponse, error) {
 
 // ListNewsletters returns a list of all newsletters configured on the Ghost site.
 func (c *Client) ListNewsletters() (*NewslettersResponse, error) {
+	return c.ListNewslettersContext(context.Background())
+}
+
+// ListNewslettersContext is like ListNewsletters but uses ctx for the request.
+func (c *Client) ListNewslettersContext(ctx context.Context) (*NewslettersResponse, error) {
+	return c.ListNewslettersWithOptions(ctx, nil)
+}
+
+// ListNewslettersWithOptions returns newsletters matching opts. A nil opts lists the first page.
+func (c *Client) ListNewslettersWithOptions(ctx context.Context, opts *ListOptions) (*NewslettersResponse, error) {
 	var resp NewslettersResponse
-	if err := c.do("GET", "/newsletters/", nil, &resp); err != nil {
+	if err := c.do(ctx, "GET", withQuery("/newsletters/", opts.values()), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -441,8 +869,18 @@ func (c *Client) ListNewsletters() (*NewslettersResponse, error) {
 
 // GetNewsletter returns a single newsletter by ID.
 func (c *Client) GetNewsletter(id string) (*Newsletter, error) {
+	return c.GetNewsletterContext(context.Background(), id)
+}
+
+// GetNewsletterContext is like GetNewsletter but uses ctx for the request.
+func (c *Client) GetNewsletterContext(ctx context.Context, id string) (*Newsletter, error) {
+	return c.GetNewsletterWithOptions(ctx, id, nil)
+}
+
+// GetNewsletterWithOptions is like GetNewsletterContext but requests the related data and fields in opts.
+func (c *Client) GetNewsletterWithOptions(ctx context.Context, id string, opts *GetOptions) (*Newsletter, error) {
 	var resp NewslettersResponse
-	if err := c.do("GET", "/newsletters/"+id+"/", nil, &resp); err != nil {
+	if err := c.do(ctx, "GET", withQuery("/newsletters/"+url.PathEscape(id)+"/", opts.values()), nil, &resp); err != nil {
 		return nil, err
 	}
 	if len(resp.Newsletters) == 0 {
@@ -451,13 +889,64 @@ func (c *Client) GetNewsletter(id string) (*Newsletter, error) {
 	return &resp.Newsletters[0], nil
 }
 
+// Emails
+
+// GetEmail returns the newsletter email with the given ID, including its
+// delivery status and counts. Post.Email.ID identifies the email for a post.
+func (c *Client) GetEmail(id string) (*Email, error) {
+	return c.GetEmailContext(context.Background(), id)
+}
+
+// GetEmailContext is like GetEmail but uses ctx for the request.
+func (c *Client) GetEmailContext(ctx context.Context, id string) (*Email, error) {
+	var resp EmailsResponse
+	if err := c.do(ctx, "GET", "/emails/"+url.PathEscape(id)+"/", nil, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Emails) == 0 {
+		return nil, fmt.Errorf("email not found: %s", id)
+	}
+	return &resp.Emails[0], nil
+}
+
+// Members
+
+// ListMembers returns the first page of members.
+func (c *Client) ListMembers() (*MembersResponse, error) {
+	return c.ListMembersContext(context.Background())
+}
+
+// ListMembersContext is like ListMembers but uses ctx for the request.
+func (c *Client) ListMembersContext(ctx context.Context) (*MembersResponse, error) {
+	return c.ListMembersWithOptions(ctx, nil)
+}
+
+// ListMembersWithOptions returns members matching opts. A nil opts lists the first page.
+func (c *Client) ListMembersWithOptions(ctx context.Context, opts *ListOptions) (*MembersResponse, error) {
+	var resp MembersResponse
+	if err := c.do(ctx, "GET", withQuery("/members/", opts.values()), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // Webhooks
 
 // ListWebhooks returns a list of all webhooks.
 // Note: This endpoint may not be available in all Ghost versions.
 func (c *Client) ListWebhooks() (*WebhooksResponse, error) {
+	return c.ListWebhooksContext(context.Background())
+}
+
+// ListWebhooksContext is like ListWebhooks but uses ctx for the request.
+func (c *Client) ListWebhooksContext(ctx context.Context) (*WebhooksResponse, error) {
+	return c.ListWebhooksWithOptions(ctx, nil)
+}
+
+// ListWebhooksWithOptions returns webhooks matching opts. A nil opts lists the first page.
+func (c *Client) ListWebhooksWithOptions(ctx context.Context, opts *ListOptions) (*WebhooksResponse, error) {
 	var resp WebhooksResponse
-	if err := c.do("GET", "/webhooks/", nil, &resp); err != nil {
+	if err := c.do(ctx, "GET", withQuery("/webhooks/", opts.values()), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -466,9 +955,14 @@ func (c *Client) ListWebhooks() (*WebhooksResponse, error) {
 // CreateWebhook creates a new webhook.
 // The webhook should have Event and TargetURL set at minimum.
 func (c *Client) CreateWebhook(webhook *Webhook) (*Webhook, error) {
+	return c.CreateWebhookContext(context.Background(), webhook)
+}
+
+// CreateWebhookContext is like CreateWebhook but uses ctx for the request.
+func (c *Client) CreateWebhookContext(ctx context.Context, webhook *Webhook) (*Webhook, error) {
 	body := map[string][]Webhook{"webhooks": {*webhook}}
 	var resp WebhooksResponse
-	if err := c.do("POST", "/webhooks/", body, &resp); err != nil {
+	if err := c.do(ctx, "POST", "/webhooks/", body, &resp); err != nil {
 		return nil, err
 	}
 	if len(resp.Webhooks) == 0 {
@@ -479,7 +973,12 @@ func (c *Client) CreateWebhook(webhook *Webhook) (*Webhook, error) {
 
 // DeleteWebhook permanently deletes a webhook by ID.
 func (c *Client) DeleteWebhook(id string) error {
-	return c.do("DELETE", "/webhooks/"+id+"/", nil, nil)
+	return c.DeleteWebhookContext(context.Background(), id)
+}
+
+// DeleteWebhookContext is like DeleteWebhook but uses ctx for the request.
+func (c *Client) DeleteWebhookContext(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/webhooks/"+url.PathEscape(id)+"/", nil, nil)
 }
 
 // Images
@@ -487,54 +986,13 @@ func (c *Client) DeleteWebhook(id string) error {
 // UploadImage uploads an image file to Ghost and returns the URL.
 // The filePath should be a path to an image file on the local filesystem.
 func (c *Client) UploadImage(filePath string) (*ImagesResponse, error) {
-	token, err := GenerateToken(c.apiKey)
-	if err != nil {
-		return nil, fmt.Errorf("generating token: %w", err)
-	}
-
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
-
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
-	if err != nil {
-		return nil, err
-	}
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, err
-	}
-	writer.Close()
-
-	req, err := http.NewRequest("POST", c.baseURL+"/images/upload/", &buf)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", "Ghost "+token)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("upload failed (%d): %s", resp.StatusCode, string(respBody))
-	}
+	return c.UploadImageContext(context.Background(), filePath)
+}
 
+// UploadImageContext is like UploadImage but uses ctx for the request.
+func (c *Client) UploadImageContext(ctx context.Context, filePath string) (*ImagesResponse, error) {
 	var result ImagesResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.uploadFile(ctx, "/images/upload/", filePath, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -544,49 +1002,10 @@ func (c *Client) UploadImage(filePath string) (*ImagesResponse, error) {
 // This is useful when the image data is not coming from a file.
 // The filename parameter is used for the Content-Disposition header.
 func (c *Client) UploadImageReader(r io.Reader, filename string) (*ImagesResponse, error) {
-	token, err := GenerateToken(c.apiKey)
-	if err != nil {
-		return nil, fmt.Errorf("generating token: %w", err)
-	}
-
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
-
-	part, err := writer.CreateFormFile("file", filename)
-	if err != nil {
-		return nil, err
-	}
-	if _, err := io.Copy(part, r); err != nil {
-		return nil, err
-	}
-	writer.Close()
-
-	req, err := http.NewRequest("POST", c.baseURL+"/images/upload/", &buf)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", "Ghost "+token)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("upload failed (%d): %s", resp.StatusCode, string(respBody))
-	}
+	return c.UploadImageReaderContext(context.Background(), r, filename)
+}
 
-	var result ImagesResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+// UploadImageReaderContext is like UploadImageReader but uses ctx for the request.
+func (c *Client) UploadImageReaderContext(ctx context.Context, r io.Reader, filename string) (*ImagesResponse, error) {
+	return c.UploadImageWithOptions(ctx, r, filename, nil)
 }
@@ -1,14 +1,18 @@
 package libecto
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -94,7 +98,7 @@ func TestClient_ListPosts(t *testing.T) {
 			response:   PostsResponse{Posts: []Post{{ID: "2", Status: "published"}}},
 			statusCode: 200,
 			checkPath: func(t *testing.T, path string) {
-				assert.Contains(t, path, "filter=status:published")
+				assert.Contains(t, path, "filter=status%3Apublished")
 			},
 		},
 		{
@@ -152,6 +156,252 @@ func TestClient_ListPosts(t *testing.T) {
 	}
 }
 
+func TestClient_ListPosts_EscapesStatus(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "status:'draft+featured:true'", r.URL.Query().Get("filter"))
+		json.NewEncoder(w).Encode(PostsResponse{})
+	})
+	defer server.Close()
+
+	_, err := client.ListPosts("draft+featured:true", 0)
+	require.NoError(t, err)
+}
+
+func TestClient_WithOptions(t *testing.T) {
+	ctx := context.Background()
+	list := &ListOptions{
+		Filter:  "tag:news+featured:true",
+		Include: []string{IncludeTags, IncludeAuthors},
+		Fields:  []string{"id", "title"},
+		Order:   "published_at desc",
+		Page:    2,
+		Limit:   LimitAll,
+	}
+	get := &GetOptions{Include: []string{IncludeTiers}, Formats: []string{FormatLexical, FormatPlaintext}}
+
+	tests := []struct {
+		name  string
+		call  func(c *Client) error
+		path  string
+		query url.Values
+	}{
+		{"posts default", func(c *Client) error { _, err := c.ListPostsWithOptions(ctx, nil); return err },
+			"/posts/", url.Values{"formats": {"html"}}},
+		{"posts", func(c *Client) error { _, err := c.ListPostsWithOptions(ctx, list); return err },
+			"/posts/", url.Values{"filter": {"tag:news+featured:true"}, "include": {"tags,authors"}, "fields": {"id,title"},
+				"formats": {"html"}, "order": {"published_at desc"}, "page": {"2"}, "limit": {"all"}}},
+		{"post", func(c *Client) error {
+			_, err := c.GetPostWithOptions(ctx, "5b7ada404f87d200b5b8d2d1", get)
+			return err
+		}, "/posts/5b7ada404f87d200b5b8d2d1/", url.Values{"include": {"tiers"}, "formats": {"lexical,plaintext"}}},
+		{"pages", func(c *Client) error {
+			_, err := c.ListPagesWithOptions(ctx, &ListOptions{Formats: []string{FormatMobiledoc}})
+			return err
+		}, "/pages/", url.Values{"formats": {"mobiledoc"}}},
+		{"page", func(c *Client) error { _, err := c.GetPageWithOptions(ctx, "about", nil); return err },
+			"/pages/slug/about/", url.Values{"formats": {"html,lexical,mobiledoc"}}},
+		{"tags", func(c *Client) error {
+			_, err := c.ListTagsWithOptions(ctx, &ListOptions{Filter: "visibility:public", Limit: 50})
+			return err
+		}, "/tags/", url.Values{"filter": {"visibility:public"}, "limit": {"50"}}},
+		{"tag", func(c *Client) error {
+			_, err := c.GetTagWithOptions(ctx, "5b7ada404f87d200b5b8d2d3", &GetOptions{Include: []string{IncludeCountPosts}})
+			return err
+		}, "/tags/5b7ada404f87d200b5b8d2d3/", url.Values{"include": {"count.posts"}}},
+		{"users", func(c *Client) error {
+			_, err := c.ListUsersWithOptions(ctx, &ListOptions{Include: []string{IncludeCountPosts}})
+			return err
+		}, "/users/", url.Values{"include": {"count.posts"}}},
+		{"user", func(c *Client) error {
+			_, err := c.GetUserWithOptions(ctx, "john", &GetOptions{Fields: []string{"name"}})
+			return err
+		}, "/users/slug/john/", url.Values{"fields": {"name"}}},
+		{"newsletters", func(c *Client) error {
+			_, err := c.ListNewslettersWithOptions(ctx, &ListOptions{Filter: "status:active"})
+			return err
+		}, "/newsletters/", url.Values{"filter": {"status:active"}}},
+		{"newsletter", func(c *Client) error {
+			_, err := c.GetNewsletterWithOptions(ctx, "n1", &GetOptions{Fields: []string{"name"}})
+			return err
+		}, "/newsletters/n1/", url.Values{"fields": {"name"}}},
+		{"webhooks", func(c *Client) error {
+			_, err := c.ListWebhooksWithOptions(ctx, &ListOptions{Limit: 5})
+			return err
+		}, "/webhooks/", url.Values{"limit": {"5"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/ghost/api/admin"+tt.path, r.URL.Path)
+				assert.Equal(t, tt.query, r.URL.Query())
+				// One item of every resource satisfies both list and get decoding.
+				w.Write([]byte(`{"posts":[{}],"pages":[{}],"tags":[{}],"users":[{}],"newsletters":[{}],"webhooks":[{}]}`))
+			})
+			defer server.Close()
+			require.NoError(t, tt.call(client))
+		})
+	}
+}
+
+func TestClient_GetPost_Fallback(t *testing.T) {
+	const id = "5b7ada404f87d200b5b8d2d9"
+	tests := []struct {
+		name      string
+		idOrSlug  string
+		idStatus  int
+		wantPaths []string
+		wantErr   func(error) bool
+	}{
+		{"not found falls back to slug", id, http.StatusNotFound,
+			[]string{"/ghost/api/admin/posts/" + id + "/", "/ghost/api/admin/posts/slug/" + id + "/"}, nil},
+		{"server error is returned", id, http.StatusInternalServerError,
+			[]string{"/ghost/api/admin/posts/" + id + "/"}, func(err error) bool { return !IsNotFound(err) }},
+		{"unauthorized is returned", id, http.StatusUnauthorized,
+			[]string{"/ghost/api/admin/posts/" + id + "/"}, IsUnauthorized},
+		// Ghost answers a non-ObjectId on the ID endpoint with 422, so slugs
+		// must skip it entirely.
+		{"slug skips the id lookup", "welcome", http.StatusUnprocessableEntity,
+			[]string{"/ghost/api/admin/posts/slug/welcome/"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				if !strings.Contains(r.URL.Path, "/slug/") {
+					w.WriteHeader(tt.idStatus)
+					json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{Message: "failed"}}})
+					return
+				}
+				json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{{ID: "p1", Slug: "welcome"}}})
+			})
+			defer server.Close()
+
+			post, err := client.GetPost(tt.idOrSlug)
+			assert.Equal(t, tt.wantPaths, paths)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, tt.wantErr(err), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "p1", post.ID)
+		})
+	}
+}
+
+func TestClient_GetByIDAndSlug(t *testing.T) {
+	var paths []string
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		w.Write([]byte(`{"posts":[{"id":"1"}],"pages":[{"id":"2"}],"tags":[{"id":"3"}],"users":[{"id":"4"}]}`))
+	})
+	defer server.Close()
+
+	post, err := client.GetPostByID("1")
+	require.NoError(t, err)
+	assert.Equal(t, "1", post.ID)
+	_, err = client.GetPostBySlug("hello world")
+	require.NoError(t, err)
+	page, err := client.GetPageByID("2")
+	require.NoError(t, err)
+	assert.Equal(t, "2", page.ID)
+	_, err = client.GetPageBySlug("about")
+	require.NoError(t, err)
+	tag, err := client.GetTagByID("3")
+	require.NoError(t, err)
+	assert.Equal(t, "3", tag.ID)
+	_, err = client.GetTagBySlug("news")
+	require.NoError(t, err)
+	user, err := client.GetUserByID("4")
+	require.NoError(t, err)
+	assert.Equal(t, "4", user.ID)
+	_, err = client.GetUserBySlug("jane")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/ghost/api/admin/posts/1/?formats=html%2Clexical%2Cmobiledoc",
+		"/ghost/api/admin/posts/slug/hello%20world/?formats=html%2Clexical%2Cmobiledoc",
+		"/ghost/api/admin/pages/2/?formats=html%2Clexical%2Cmobiledoc",
+		"/ghost/api/admin/pages/slug/about/?formats=html%2Clexical%2Cmobiledoc",
+		"/ghost/api/admin/tags/3/?",
+		"/ghost/api/admin/tags/slug/news/?",
+		"/ghost/api/admin/users/4/?",
+		"/ghost/api/admin/users/slug/jane/?",
+	}, paths)
+}
+
+func TestClient_EscapesIDs(t *testing.T) {
+	var paths []string
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.EscapedPath())
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"posts":[{"id":"a/b"}],"pages":[{}],"tags":[{}],"newsletters":[{}],"emails":[{}]}`))
+	})
+	defer server.Close()
+
+	const id = "a/b?c#d"
+	_, err := client.UpdatePost(id, &Post{})
+	require.NoError(t, err)
+	require.NoError(t, client.DeletePost(id))
+	_, err = client.UpdatePage(id, &Page{})
+	require.NoError(t, err)
+	require.NoError(t, client.DeletePage(id))
+	_, err = client.UpdateTag(id, &Tag{})
+	require.NoError(t, err)
+	require.NoError(t, client.DeleteTag(id))
+	_, err = client.GetNewsletter(id)
+	require.NoError(t, err)
+	_, err = client.GetEmail(id)
+	require.NoError(t, err)
+	require.NoError(t, client.DeleteWebhook(id))
+	_, err = client.PublishPostWithOptions(context.Background(), "a/b", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"PUT /ghost/api/admin/posts/a%2Fb%3Fc%23d/",
+		"DELETE /ghost/api/admin/posts/a%2Fb%3Fc%23d/",
+		"PUT /ghost/api/admin/pages/a%2Fb%3Fc%23d/",
+		"DELETE /ghost/api/admin/pages/a%2Fb%3Fc%23d/",
+		"PUT /ghost/api/admin/tags/a%2Fb%3Fc%23d/",
+		"DELETE /ghost/api/admin/tags/a%2Fb%3Fc%23d/",
+		"GET /ghost/api/admin/newsletters/a%2Fb%3Fc%23d/",
+		"GET /ghost/api/admin/emails/a%2Fb%3Fc%23d/",
+		"DELETE /ghost/api/admin/webhooks/a%2Fb%3Fc%23d/",
+		"GET /ghost/api/admin/posts/slug/a%2Fb/",
+		"PUT /ghost/api/admin/posts/a%2Fb/",
+	}, paths)
+}
+
+func TestClient_GetByUUID(t *testing.T) {
+	const uuid = "5b7ada404f87d200b5b8d2d9"
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("limit"))
+		if r.URL.Query().Get("filter") != "uuid:'"+uuid+"'" {
+			w.Write([]byte(`{"posts":[],"pages":[]}`))
+			return
+		}
+		w.Write([]byte(`{"posts":[{"id":"p1","uuid":"` + uuid + `"}],"pages":[{"id":"g1","uuid":"` + uuid + `"}]}`))
+	})
+	defer server.Close()
+
+	post, err := client.GetPostByUUID(uuid)
+	require.NoError(t, err)
+	assert.Equal(t, "p1", post.ID)
+	page, err := client.GetPageByUUID(uuid)
+	require.NoError(t, err)
+	assert.Equal(t, "g1", page.ID)
+
+	_, err = client.GetPostByUUID("missing")
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	_, err = client.GetPageByUUID("missing")
+	assert.True(t, IsNotFound(err))
+}
+
 func TestClient_GetPost(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -276,6 +526,48 @@ func TestClient_UpdatePost(t *testing.T) {
 	assert.Equal(t, "Updated", updated.Title)
 }
 
+func TestClient_UpdatePost_RoundTrip(t *testing.T) {
+	const lexicalDoc = `{"root":{"children":[],"direction":null,"format":"","indent":0,"type":"root","version":1}}`
+	readOnly := []string{"url", "excerpt", "reading_time", "primary_tag", "primary_author", "newsletter", "email"}
+	var update *http.Request
+	var sent map[string]interface{}
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			update = r
+			var body map[string][]map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			sent = body["posts"][0]
+		}
+		// Like Ghost, return Lexical only when it is asked for.
+		content := `"html":"<p>Hi</p>"`
+		if strings.Contains(r.URL.Query().Get("formats"), FormatLexical) {
+			content += `,"lexical":` + strconv.Quote(lexicalDoc)
+		}
+		w.Write([]byte(`{"posts":[{"id":"1","title":"Hi","updated_at":"2025-01-15T00:00:00.000Z",` + content + `,
+			"url":"https://example.com/hi/","excerpt":"Hi","reading_time":1,
+			"tags":[{"slug":"news"}],"primary_tag":{"slug":"news"},
+			"authors":[{"slug":"jo"}],"primary_author":{"slug":"jo"},
+			"newsletter":{"slug":"weekly"},"email":{"id":"e1"}}]}`))
+	})
+	defer server.Close()
+
+	post, err := client.GetPost("hi")
+	require.NoError(t, err)
+	post.MetaTitle = "Meta"
+	_, err = client.UpdatePost(post.ID, post)
+	require.NoError(t, err)
+
+	require.NotNil(t, update)
+	assert.Empty(t, update.URL.Query().Get("source"))
+	for _, field := range readOnly {
+		assert.NotContains(t, sent, field)
+	}
+	assert.Equal(t, "Meta", sent["meta_title"])
+	assert.Equal(t, lexicalDoc, sent["lexical"])
+	assert.Equal(t, "2025-01-15T00:00:00.000Z", sent["updated_at"])
+	assert.Len(t, sent["tags"], 1)
+}
+
 func TestClient_DeletePost(t *testing.T) {
 	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "DELETE", r.Method)
@@ -310,6 +602,79 @@ func TestClient_PublishPost(t *testing.T) {
 	assert.Equal(t, "published", post.Status)
 }
 
+func TestClient_PublishPostWithOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *PublishOptions
+		query     url.Values
+		emailOnly bool
+	}{
+		{
+			name:  "site only",
+			opts:  &PublishOptions{},
+			query: url.Values{"formats": {"html"}},
+		},
+		{
+			name: "newsletter",
+			opts: &PublishOptions{Newsletter: "weekly"},
+			query: url.Values{"formats": {"html"}, "newsletter": {"weekly"},
+				"include": {"email,newsletter"}},
+		},
+		{
+			name: "email only to a label",
+			opts: &PublishOptions{Newsletter: "weekly", EmailSegment: "label:vip", EmailOnly: true},
+			query: url.Values{"formats": {"html"}, "newsletter": {"weekly"}, "email_segment": {"label:vip"},
+				"include": {"email,newsletter"}},
+			emailOnly: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "GET" {
+					json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{{ID: "123", UpdatedAt: "2025-01-15"}}})
+					return
+				}
+				assert.Equal(t, "PUT", r.Method)
+				assert.Equal(t, "/ghost/api/admin/posts/123/", r.URL.Path)
+				assert.Equal(t, tt.query, r.URL.Query())
+				var body map[string][]map[string]interface{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				sent := body["posts"][0]
+				assert.Equal(t, "published", sent["status"])
+				assert.Equal(t, "2025-01-15", sent["updated_at"])
+				if tt.emailOnly {
+					assert.Equal(t, true, sent["email_only"])
+				} else {
+					assert.NotContains(t, sent, "email_only")
+				}
+				w.Write([]byte(`{"posts":[{"id":"123","status":"published","email_segment":"label:vip",
+					"newsletter":{"slug":"weekly"},"email":{"id":"e1","status":"pending","email_count":42}}]}`))
+			})
+			defer server.Close()
+
+			post, err := client.PublishPostWithOptions(context.Background(), "123", tt.opts)
+			require.NoError(t, err)
+			require.NotNil(t, post.Email)
+			assert.Equal(t, "e1", post.Email.ID)
+			assert.Equal(t, "pending", post.Email.Status)
+			assert.Equal(t, 42, post.Email.EmailCount)
+		})
+	}
+}
+
+func TestClient_PublishPostWithOptions_RequiresNewsletter(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	defer server.Close()
+
+	for _, opts := range []*PublishOptions{{EmailOnly: true}, {EmailSegment: EmailSegmentFree}} {
+		_, err := client.PublishPostWithOptions(context.Background(), "123", opts)
+		assert.ErrorContains(t, err, "requires a newsletter")
+	}
+}
+
 func TestClient_UnpublishPost(t *testing.T) {
 	callCount := 0
 	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -573,6 +938,34 @@ func TestClient_GetNewsletter_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "newsletter not found")
 }
 
+// Emails tests
+
+func TestClient_GetEmail(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ghost/api/admin/emails/e1/", r.URL.Path)
+		w.Write([]byte(`{"emails":[{"id":"e1","status":"submitted","recipient_filter":"status:free",
+			"email_count":10,"delivered_count":9,"failed_count":1}]}`))
+	})
+	defer server.Close()
+
+	email, err := client.GetEmail("e1")
+	require.NoError(t, err)
+	assert.Equal(t, "submitted", email.Status)
+	assert.Equal(t, "status:free", email.RecipientFilter)
+	assert.Equal(t, 9, email.DeliveredCount)
+	assert.Equal(t, 1, email.FailedCount)
+}
+
+func TestClient_GetEmail_NotFound(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(EmailsResponse{Emails: []Email{}})
+	})
+	defer server.Close()
+
+	_, err := client.GetEmail("nonexistent")
+	assert.ErrorContains(t, err, "email not found")
+}
+
 // Webhooks tests
 
 func TestClient_ListWebhooks(t *testing.T) {
@@ -847,77 +1240,170 @@ func TestClient_UploadImageReader_ServerError(t *testing.T) {
 
 // Fallback path tests (ID -> slug)
 
-func TestClient_GetPost_FallbackToSlug(t *testing.T) {
-	callCount := 0
-	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
-		callCount++
-		if callCount == 1 {
-			// First try by ID fails
-			w.WriteHeader(404)
-			json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{Message: "Not found"}}})
-		} else {
-			// Second try by slug succeeds
-			json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{{ID: "123", Slug: "my-post"}}})
+// objectIDNotFound answers the ID endpoint with 404 for an ObjectId and 422
+// for anything else, the way Ghost does, and serves body on slug lookups.
+func objectIDNotFound(paths *[]string, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		if strings.Contains(r.URL.Path, "/slug/") {
+			w.Write([]byte(body))
+			return
 		}
-	})
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if IsObjectID(parts[len(parts)-1]) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{Message: "Not found", Type: "NotFoundError"}}})
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{Message: "Validation error, cannot read post.", Type: "ValidationError"}}})
+	}
+}
+
+func TestClient_GetPost_FallbackToSlug(t *testing.T) {
+	var paths []string
+	server, client := newTestServer(t, objectIDNotFound(&paths, `{"posts":[{"id":"123","slug":"my-post"}]}`))
 	defer server.Close()
 
-	post, err := client.GetPost("my-post")
+	post, err := client.GetPost("5b7ada404f87d200b5b8d2d9")
 	require.NoError(t, err)
 	assert.Equal(t, "123", post.ID)
-	assert.Equal(t, 2, callCount)
+	assert.Equal(t, []string{
+		"/ghost/api/admin/posts/5b7ada404f87d200b5b8d2d9/",
+		"/ghost/api/admin/posts/slug/5b7ada404f87d200b5b8d2d9/",
+	}, paths)
+
+	paths = nil
+	post, err = client.GetPost("my-post")
+	require.NoError(t, err)
+	assert.Equal(t, "123", post.ID)
+	assert.Equal(t, []string{"/ghost/api/admin/posts/slug/my-post/"}, paths)
 }
 
 func TestClient_GetPage_FallbackToSlug(t *testing.T) {
-	callCount := 0
-	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
-		callCount++
-		if callCount == 1 {
-			w.WriteHeader(404)
-			json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{Message: "Not found"}}})
-		} else {
-			json.NewEncoder(w).Encode(PagesResponse{Pages: []Page{{ID: "123", Slug: "about"}}})
-		}
-	})
+	var paths []string
+	server, client := newTestServer(t, objectIDNotFound(&paths, `{"pages":[{"id":"123","slug":"about"}]}`))
 	defer server.Close()
 
-	page, err := client.GetPage("about")
+	page, err := client.GetPage("5B7ADA404F87D200B5B8D2D9")
+	require.NoError(t, err)
+	assert.Equal(t, "123", page.ID)
+	assert.Len(t, paths, 2)
+
+	paths = nil
+	page, err = client.GetPage("about")
 	require.NoError(t, err)
 	assert.Equal(t, "123", page.ID)
+	assert.Equal(t, []string{"/ghost/api/admin/pages/slug/about/"}, paths)
 }
 
 func TestClient_GetTag_FallbackToSlug(t *testing.T) {
-	callCount := 0
-	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
-		callCount++
-		if callCount == 1 {
-			w.WriteHeader(404)
-			json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{Message: "Not found"}}})
-		} else {
-			json.NewEncoder(w).Encode(TagsResponse{Tags: []Tag{{ID: "123", Slug: "tech"}}})
-		}
-	})
+	var paths []string
+	server, client := newTestServer(t, objectIDNotFound(&paths, `{"tags":[{"id":"123","slug":"tech"}]}`))
 	defer server.Close()
 
-	tag, err := client.GetTag("tech")
+	tag, err := client.GetTag("5b7ada404f87d200b5b8d2d9")
+	require.NoError(t, err)
+	assert.Equal(t, "123", tag.ID)
+	assert.Len(t, paths, 2)
+
+	paths = nil
+	tag, err = client.GetTag("tech")
 	require.NoError(t, err)
 	assert.Equal(t, "123", tag.ID)
+	assert.Equal(t, []string{"/ghost/api/admin/tags/slug/tech/"}, paths)
 }
 
 func TestClient_GetUser_FallbackToSlug(t *testing.T) {
-	callCount := 0
+	var paths []string
+	server, client := newTestServer(t, objectIDNotFound(&paths, `{"users":[{"id":"123","slug":"john"}]}`))
+	defer server.Close()
+
+	user, err := client.GetUser("5b7ada404f87d200b5b8d2d9")
+	require.NoError(t, err)
+	assert.Equal(t, "123", user.ID)
+	assert.Len(t, paths, 2)
+
+	paths = nil
+	user, err = client.GetUser("john")
+	require.NoError(t, err)
+	assert.Equal(t, "123", user.ID)
+	assert.Equal(t, []string{"/ghost/api/admin/users/slug/john/"}, paths)
+}
+
+func TestIsObjectID(t *testing.T) {
+	assert.True(t, IsObjectID("5b7ada404f87d200b5b8d2d9"))
+	assert.True(t, IsObjectID("5B7ADA404F87D200B5B8D2D9"))
+	assert.False(t, IsObjectID("5b7ada404f87d200b5b8d2d"))
+	assert.False(t, IsObjectID("5b7ada404f87d200b5b8d2dg"))
+	assert.False(t, IsObjectID("welcome"))
+	assert.False(t, IsObjectID(""))
+}
+
+// Context tests
+
+func TestClient_ListPostsContext_Canceled(t *testing.T) {
+	called := false
 	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
-		callCount++
-		if callCount == 1 {
-			w.WriteHeader(404)
-			json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{Message: "Not found"}}})
-		} else {
-			json.NewEncoder(w).Encode(UsersResponse{Users: []Author{{ID: "123", Slug: "john"}}})
+		called = true
+		json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{}})
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.ListPostsContext(ctx, "", 0)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
+
+func TestClient_GetPostContext_Deadline(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetPostContext(ctx, "123")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_UploadImageReaderContext_Canceled(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.UploadImageReaderContext(ctx, strings.NewReader("data"), "test.jpg")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClient_PublishPostContext(t *testing.T) {
+	var seen []string
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Method)
+		if r.Method == "GET" {
+			json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{{ID: "123", UpdatedAt: "2025-01-15"}}})
+			return
 		}
+		json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{{ID: "123", Status: "published"}}})
 	})
 	defer server.Close()
 
-	user, err := client.GetUser("john")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	post, err := client.PublishPostContext(ctx, "123")
 	require.NoError(t, err)
-	assert.Equal(t, "123", user.ID)
+	assert.Equal(t, "published", post.Status)
+	assert.Equal(t, []string{"GET", "PUT"}, seen)
 }
@@ -0,0 +1,259 @@
+package libecto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Environment variables read by LoadConfig and NewClientFromEnv.
+const (
+	// EnvURL overrides the URL of the active site.
+	EnvURL = "GHOST_URL"
+	// EnvAdminKey overrides the Admin API key of the active site.
+	EnvAdminKey = "GHOST_ADMIN_KEY"
+	// EnvSite selects the active site by name.
+	EnvSite = "GHOST_SITE"
+)
+
+// SiteConfig holds the connection details for a single Ghost site.
+type SiteConfig struct {
+	// URL is the Ghost site URL (e.g., "https://mysite.ghost.io").
+	URL string `json:"url"`
+	// APIKey is the Admin API key in "id:secret" format.
+	APIKey string `json:"api_key"`
+}
+
+// Config is the multi-site configuration shared with the ecto CLI.
+// It is stored as JSON at ~/.config/ecto/config.json by default.
+type Config struct {
+	// DefaultSite is the name of the site used when none is specified.
+	DefaultSite string `json:"default_site,omitempty"`
+	// Sites maps site names to their connection details.
+	Sites map[string]SiteConfig `json:"sites"`
+}
+
+// DefaultConfigPath returns the default config file location,
+// $XDG_CONFIG_HOME/ecto/config.json or ~/.config/ecto/config.json.
+func DefaultConfigPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "ecto", "config.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locating config: %w", err)
+	}
+	return filepath.Join(home, ".config", "ecto", "config.json"), nil
+}
+
+// LoadConfig loads the config from the default path.
+// A missing file is not an error: an empty Config is returned so that
+// sites can still be supplied through environment variables.
+func LoadConfig() (*Config, error) {
+	path, err := DefaultConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadConfigFile(path)
+}
+
+// LoadConfigFile loads the config from path.
+// A missing file yields an empty Config.
+func LoadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{Sites: map[string]SiteConfig{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	if cfg.Sites == nil {
+		cfg.Sites = map[string]SiteConfig{}
+	}
+	return &cfg, nil
+}
+
+// Save writes the config to the default path.
+func (c *Config) Save() error {
+	path, err := DefaultConfigPath()
+	if err != nil {
+		return err
+	}
+	return c.SaveFile(path)
+}
+
+// SaveFile writes the config to path, creating parent directories as needed.
+// The file is written atomically with 0600 permissions since it contains API keys.
+func (c *Config) SaveFile(path string) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating config dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".config-*.json")
+	if err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+// AddSite validates and stores a site under name.
+// The first site added becomes the default site.
+func (c *Config) AddSite(name string, site SiteConfig) error {
+	if name == "" {
+		return fmt.Errorf("site name cannot be empty")
+	}
+	if err := site.Validate(); err != nil {
+		return fmt.Errorf("site %q: %w", name, err)
+	}
+	if c.Sites == nil {
+		c.Sites = map[string]SiteConfig{}
+	}
+	c.Sites[name] = site
+	if c.DefaultSite == "" {
+		c.DefaultSite = name
+	}
+	return nil
+}
+
+// RemoveSite deletes the named site, clearing DefaultSite if it pointed to it.
+func (c *Config) RemoveSite(name string) {
+	delete(c.Sites, name)
+	if c.DefaultSite == name {
+		c.DefaultSite = ""
+	}
+}
+
+// SiteNames returns the configured site names in sorted order.
+func (c *Config) SiteNames() []string {
+	names := make([]string, 0, len(c.Sites))
+	for name := range c.Sites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks every site and that DefaultSite refers to a configured site.
+func (c *Config) Validate() error {
+	for _, name := range c.SiteNames() {
+		if err := c.Sites[name].Validate(); err != nil {
+			return fmt.Errorf("site %q: %w", name, err)
+		}
+	}
+	if c.DefaultSite != "" {
+		if _, ok := c.Sites[c.DefaultSite]; !ok {
+			return fmt.Errorf("default site %q is not configured", c.DefaultSite)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the URL is an absolute http(s) URL and that the
+// API key parses with ParseAPIKey.
+func (s SiteConfig) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid site URL %q: expected http(s)://host", s.URL)
+	}
+	if _, _, err := ParseAPIKey(s.APIKey); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ActiveSite resolves the site to use. The site is chosen by name, then
+// GHOST_SITE, then DefaultSite, then the only configured site. When no site is
+// named by the argument or GHOST_SITE, GHOST_URL and GHOST_ADMIN_KEY together
+// replace the configured site; setting only one of them is an error, so a
+// site's key is never sent to a different host.
+func (c *Config) ActiveSite(name string) (SiteConfig, error) {
+	if name == "" {
+		name = os.Getenv(EnvSite)
+	}
+	if name == "" {
+		envURL, envKey := os.Getenv(EnvURL), os.Getenv(EnvAdminKey)
+		switch {
+		case envURL != "" && envKey != "":
+			site := SiteConfig{URL: envURL, APIKey: envKey}
+			if err := site.Validate(); err != nil {
+				return SiteConfig{}, err
+			}
+			return site, nil
+		case envURL != "" || envKey != "":
+			return SiteConfig{}, fmt.Errorf("%s and %s must be set together", EnvURL, EnvAdminKey)
+		}
+		name = c.DefaultSite
+	}
+	if name == "" && len(c.Sites) == 1 {
+		name = c.SiteNames()[0]
+	}
+	if name == "" {
+		return SiteConfig{}, fmt.Errorf("no site configured: add one to the config or set %s and %s", EnvURL, EnvAdminKey)
+	}
+
+	site, ok := c.Sites[name]
+	if !ok {
+		return SiteConfig{}, fmt.Errorf("site %q is not configured", name)
+	}
+	if err := site.Validate(); err != nil {
+		return SiteConfig{}, err
+	}
+	return site, nil
+}
+
+// GetActiveClient returns a Client for the named site, resolved as described
+// in ActiveSite. Pass an empty name to use the default site.
+func (c *Config) GetActiveClient(name string, opts ...ClientOption) (*Client, error) {
+	site, err := c.ActiveSite(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(site.URL, site.APIKey, opts...), nil
+}
+
+// NewClientFromEnv creates a Client from the environment. GHOST_URL and
+// GHOST_ADMIN_KEY are used directly when both are set; otherwise the site is
+// resolved from the default config file, honoring GHOST_SITE.
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	if os.Getenv(EnvURL) != "" && os.Getenv(EnvAdminKey) != "" && os.Getenv(EnvSite) == "" {
+		return (&Config{}).GetActiveClient("", opts...)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.GetActiveClient("", opts...)
+}
@@ -0,0 +1,239 @@
+package libecto
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearConfigEnv isolates a test from the caller's Ghost environment and home directory.
+func clearConfigEnv(t *testing.T) string {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv(EnvURL, "")
+	t.Setenv(EnvAdminKey, "")
+	t.Setenv(EnvSite, "")
+	return dir
+}
+
+func testConfig(t *testing.T) *Config {
+	cfg := &Config{}
+	require.NoError(t, cfg.AddSite("blog", SiteConfig{URL: "https://blog.example.com", APIKey: validAPIKey}))
+	require.NoError(t, cfg.AddSite("docs", SiteConfig{URL: "https://docs.example.com", APIKey: testAPIKey}))
+	return cfg
+}
+
+func TestDefaultConfigPath(t *testing.T) {
+	dir := clearConfigEnv(t)
+	path, err := DefaultConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "ecto", "config.json"), path)
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/home/ghost")
+	path, err = DefaultConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/home/ghost/.config/ecto/config.json", path)
+}
+
+func TestLoadConfig_Missing(t *testing.T) {
+	clearConfigEnv(t)
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Sites)
+	assert.Empty(t, cfg.DefaultSite)
+}
+
+func TestLoadConfigFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+
+	_, err := LoadConfigFile(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parsing config")
+}
+
+func TestConfig_SaveAndLoad(t *testing.T) {
+	dir := clearConfigEnv(t)
+	cfg := testConfig(t)
+	require.NoError(t, cfg.Save())
+
+	path := filepath.Join(dir, "ecto", "config.json")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+	assert.Equal(t, "blog", loaded.DefaultSite)
+	assert.Equal(t, []string{"blog", "docs"}, loaded.SiteNames())
+}
+
+func TestConfig_SaveFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := &Config{DefaultSite: "missing"}
+	err := cfg.SaveFile(path)
+	require.Error(t, err)
+	assert.NoFileExists(t, path)
+}
+
+func TestConfig_AddSite_Validation(t *testing.T) {
+	cfg := &Config{}
+	assert.Error(t, cfg.AddSite("", SiteConfig{URL: "https://a.example.com", APIKey: validAPIKey}))
+	assert.Error(t, cfg.AddSite("a", SiteConfig{URL: "a.example.com", APIKey: validAPIKey}))
+	assert.Error(t, cfg.AddSite("a", SiteConfig{URL: "ftp://a.example.com", APIKey: validAPIKey}))
+	assert.Error(t, cfg.AddSite("a", SiteConfig{URL: "https://a.example.com", APIKey: malformedKey}))
+	assert.Empty(t, cfg.Sites)
+}
+
+func TestConfig_RemoveSite(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.RemoveSite("blog")
+	assert.Empty(t, cfg.DefaultSite)
+	assert.Equal(t, []string{"docs"}, cfg.SiteNames())
+	require.NoError(t, cfg.Validate())
+}
+
+func TestConfig_ActiveSite(t *testing.T) {
+	clearConfigEnv(t)
+	cfg := testConfig(t)
+
+	site, err := cfg.ActiveSite("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://blog.example.com", site.URL)
+
+	site, err = cfg.ActiveSite("docs")
+	require.NoError(t, err)
+	assert.Equal(t, "https://docs.example.com", site.URL)
+
+	_, err = cfg.ActiveSite("missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"missing"`)
+}
+
+func TestConfig_ActiveSite_SingleSite(t *testing.T) {
+	clearConfigEnv(t)
+	cfg := testConfig(t)
+	cfg.RemoveSite("blog")
+
+	site, err := cfg.ActiveSite("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://docs.example.com", site.URL)
+}
+
+func TestConfig_ActiveSite_EnvSite(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(EnvSite, "docs")
+	cfg := testConfig(t)
+
+	site, err := cfg.ActiveSite("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://docs.example.com", site.URL)
+
+	// An explicit name wins over GHOST_SITE.
+	site, err = cfg.ActiveSite("blog")
+	require.NoError(t, err)
+	assert.Equal(t, "https://blog.example.com", site.URL)
+}
+
+func TestConfig_ActiveSite_EnvOverrides(t *testing.T) {
+	clearConfigEnv(t)
+	cfg := testConfig(t)
+
+	// A URL without a key must not pick up the configured site's key.
+	t.Setenv(EnvURL, "https://staging.example.com")
+	_, err := cfg.ActiveSite("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be set together")
+
+	t.Setenv(EnvAdminKey, testAPIKey)
+	site, err := cfg.ActiveSite("")
+	require.NoError(t, err)
+	assert.Equal(t, SiteConfig{URL: "https://staging.example.com", APIKey: testAPIKey}, site)
+
+	// An explicitly named site ignores the environment pair.
+	site, err = cfg.ActiveSite("blog")
+	require.NoError(t, err)
+	assert.Equal(t, "https://blog.example.com", site.URL)
+	assert.Equal(t, validAPIKey, site.APIKey)
+
+	t.Setenv(EnvSite, "docs")
+	site, err = cfg.ActiveSite("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://docs.example.com", site.URL)
+	t.Setenv(EnvSite, "")
+
+	t.Setenv(EnvAdminKey, malformedKey)
+	_, err = cfg.ActiveSite("")
+	require.Error(t, err)
+}
+
+func TestConfig_ActiveSite_EnvOnly(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(EnvAdminKey, testAPIKey)
+	_, err := (&Config{}).ActiveSite("")
+	require.Error(t, err)
+
+	t.Setenv(EnvURL, "https://env.example.com")
+	site, err := (&Config{}).ActiveSite("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://env.example.com", site.URL)
+
+	_, err = (&Config{}).ActiveSite("missing")
+	assert.ErrorContains(t, err, `"missing"`)
+}
+
+func TestConfig_ActiveSite_NoneConfigured(t *testing.T) {
+	clearConfigEnv(t)
+	_, err := (&Config{}).ActiveSite("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), EnvURL)
+}
+
+func TestConfig_GetActiveClient(t *testing.T) {
+	clearConfigEnv(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"site":{"title":"Docs"}}`))
+	}))
+	defer server.Close()
+
+	cfg := testConfig(t)
+	cfg.Sites["docs"] = SiteConfig{URL: server.URL, APIKey: testAPIKey}
+
+	client, err := cfg.GetActiveClient("docs")
+	require.NoError(t, err)
+	site, err := client.GetSite()
+	require.NoError(t, err)
+	assert.Equal(t, "Docs", site.Title)
+
+	_, err = cfg.GetActiveClient("missing")
+	require.Error(t, err)
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	clearConfigEnv(t)
+	_, err := NewClientFromEnv()
+	require.Error(t, err)
+
+	t.Setenv(EnvURL, "https://env.example.com")
+	t.Setenv(EnvAdminKey, testAPIKey)
+	client, err := NewClientFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "https://env.example.com/ghost/api/admin", client.baseURL)
+}
+
+func TestNewClientFromEnv_ConfigFile(t *testing.T) {
+	clearConfigEnv(t)
+	require.NoError(t, testConfig(t).Save())
+	t.Setenv(EnvSite, "docs")
+
+	client, err := NewClientFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "https://docs.example.com/ghost/api/admin", client.baseURL)
+}
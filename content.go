@@ -0,0 +1,270 @@
+package libecto
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ContentClient is a read-only Ghost Content API client.
+// It authenticates with a Content API key sent as the "key" query parameter
+// and returns the same Post, Page, Tag and Author types as the Admin Client.
+//
+// ContentClient accepts the same ClientOption values as NewClient, so retries,
+// rate limiting, middleware and logging work identically. Options that only
+// apply to Admin authentication, such as WithTokenSource, are ignored: a
+// ContentClient never sends an Admin token, even when its key is empty.
+type ContentClient struct {
+	client *Client
+}
+
+// NewContentClient creates a new Ghost Content API client.
+// The url parameter is the Ghost site URL (e.g., "https://mysite.ghost.io").
+// The contentKey parameter is the Content API key from a custom integration;
+// if it is empty, every request fails without reaching the site.
+func NewContentClient(url, contentKey string, opts ...ClientOption) *ContentClient {
+	url = strings.TrimSuffix(url, "/")
+	c := &Client{
+		baseURL:    url + "/ghost/api/content",
+		content:    true,
+		contentKey: contentKey,
+		httpClient: &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.build()
+	return &ContentClient{client: c}
+}
+
+// BaseURL returns the base URL for API requests.
+func (c *ContentClient) BaseURL() string {
+	return c.client.baseURL
+}
+
+// get performs a GET request with the given query options.
+func (c *ContentClient) get(ctx context.Context, path string, opts queryOptions, result interface{}) error {
+	return c.client.do(ctx, "GET", withQuery(path, opts.values()), nil, result)
+}
+
+// Posts
+
+// ListPosts returns published posts matching opts. A nil opts uses the server defaults.
+func (c *ContentClient) ListPosts(opts *ListOptions) (*PostsResponse, error) {
+	return c.ListPostsContext(context.Background(), opts)
+}
+
+// ListPostsContext is like ListPosts but uses ctx for the request.
+func (c *ContentClient) ListPostsContext(ctx context.Context, opts *ListOptions) (*PostsResponse, error) {
+	var resp PostsResponse
+	if err := c.get(ctx, "/posts/", opts, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetPost returns a published post by ID.
+func (c *ContentClient) GetPost(id string, opts *GetOptions) (*Post, error) {
+	return c.GetPostContext(context.Background(), id, opts)
+}
+
+// GetPostContext is like GetPost but uses ctx for the request.
+func (c *ContentClient) GetPostContext(ctx context.Context, id string, opts *GetOptions) (*Post, error) {
+	return c.getPost(ctx, "/posts/"+url.PathEscape(id)+"/", id, opts)
+}
+
+// GetPostBySlug returns a published post by slug.
+func (c *ContentClient) GetPostBySlug(slug string, opts *GetOptions) (*Post, error) {
+	return c.GetPostBySlugContext(context.Background(), slug, opts)
+}
+
+// GetPostBySlugContext is like GetPostBySlug but uses ctx for the request.
+func (c *ContentClient) GetPostBySlugContext(ctx context.Context, slug string, opts *GetOptions) (*Post, error) {
+	return c.getPost(ctx, "/posts/slug/"+url.PathEscape(slug)+"/", slug, opts)
+}
+
+func (c *ContentClient) getPost(ctx context.Context, path, ref string, opts *GetOptions) (*Post, error) {
+	var resp PostsResponse
+	if err := c.get(ctx, path, opts, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Posts) == 0 {
+		return nil, fmt.Errorf("post not found: %s", ref)
+	}
+	return &resp.Posts[0], nil
+}
+
+// Pages
+
+// ListPages returns published pages matching opts. A nil opts uses the server defaults.
+func (c *ContentClient) ListPages(opts *ListOptions) (*PagesResponse, error) {
+	return c.ListPagesContext(context.Background(), opts)
+}
+
+// ListPagesContext is like ListPages but uses ctx for the request.
+func (c *ContentClient) ListPagesContext(ctx context.Context, opts *ListOptions) (*PagesResponse, error) {
+	var resp PagesResponse
+	if err := c.get(ctx, "/pages/", opts, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetPage returns a published page by ID.
+func (c *ContentClient) GetPage(id string, opts *GetOptions) (*Page, error) {
+	return c.GetPageContext(context.Background(), id, opts)
+}
+
+// GetPageContext is like GetPage but uses ctx for the request.
+func (c *ContentClient) GetPageContext(ctx context.Context, id string, opts *GetOptions) (*Page, error) {
+	return c.getPage(ctx, "/pages/"+url.PathEscape(id)+"/", id, opts)
+}
+
+// GetPageBySlug returns a published page by slug.
+func (c *ContentClient) GetPageBySlug(slug string, opts *GetOptions) (*Page, error) {
+	return c.GetPageBySlugContext(context.Background(), slug, opts)
+}
+
+// GetPageBySlugContext is like GetPageBySlug but uses ctx for the request.
+func (c *ContentClient) GetPageBySlugContext(ctx context.Context, slug string, opts *GetOptions) (*Page, error) {
+	return c.getPage(ctx, "/pages/slug/"+url.PathEscape(slug)+"/", slug, opts)
+}
+
+func (c *ContentClient) getPage(ctx context.Context, path, ref string, opts *GetOptions) (*Page, error) {
+	var resp PagesResponse
+	if err := c.get(ctx, path, opts, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Pages) == 0 {
+		return nil, fmt.Errorf("page not found: %s", ref)
+	}
+	return &resp.Pages[0], nil
+}
+
+// Tags
+
+// ListTags returns public tags matching opts. A nil opts uses the server defaults.
+func (c *ContentClient) ListTags(opts *ListOptions) (*TagsResponse, error) {
+	return c.ListTagsContext(context.Background(), opts)
+}
+
+// ListTagsContext is like ListTags but uses ctx for the request.
+func (c *ContentClient) ListTagsContext(ctx context.Context, opts *ListOptions) (*TagsResponse, error) {
+	var resp TagsResponse
+	if err := c.get(ctx, "/tags/", opts, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetTag returns a tag by ID.
+func (c *ContentClient) GetTag(id string, opts *GetOptions) (*Tag, error) {
+	return c.GetTagContext(context.Background(), id, opts)
+}
+
+// GetTagContext is like GetTag but uses ctx for the request.
+func (c *ContentClient) GetTagContext(ctx context.Context, id string, opts *GetOptions) (*Tag, error) {
+	return c.getTag(ctx, "/tags/"+url.PathEscape(id)+"/", id, opts)
+}
+
+// GetTagBySlug returns a tag by slug.
+func (c *ContentClient) GetTagBySlug(slug string, opts *GetOptions) (*Tag, error) {
+	return c.GetTagBySlugContext(context.Background(), slug, opts)
+}
+
+// GetTagBySlugContext is like GetTagBySlug but uses ctx for the request.
+func (c *ContentClient) GetTagBySlugContext(ctx context.Context, slug string, opts *GetOptions) (*Tag, error) {
+	return c.getTag(ctx, "/tags/slug/"+url.PathEscape(slug)+"/", slug, opts)
+}
+
+func (c *ContentClient) getTag(ctx context.Context, path, ref string, opts *GetOptions) (*Tag, error) {
+	var resp TagsResponse
+	if err := c.get(ctx, path, opts, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Tags) == 0 {
+		return nil, fmt.Errorf("tag not found: %s", ref)
+	}
+	return &resp.Tags[0], nil
+}
+
+// Authors
+
+// ListAuthors returns authors matching opts. A nil opts uses the server defaults.
+func (c *ContentClient) ListAuthors(opts *ListOptions) (*AuthorsResponse, error) {
+	return c.ListAuthorsContext(context.Background(), opts)
+}
+
+// ListAuthorsContext is like ListAuthors but uses ctx for the request.
+func (c *ContentClient) ListAuthorsContext(ctx context.Context, opts *ListOptions) (*AuthorsResponse, error) {
+	var resp AuthorsResponse
+	if err := c.get(ctx, "/authors/", opts, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAuthor returns an author by ID.
+func (c *ContentClient) GetAuthor(id string, opts *GetOptions) (*Author, error) {
+	return c.GetAuthorContext(context.Background(), id, opts)
+}
+
+// GetAuthorContext is like GetAuthor but uses ctx for the request.
+func (c *ContentClient) GetAuthorContext(ctx context.Context, id string, opts *GetOptions) (*Author, error) {
+	return c.getAuthor(ctx, "/authors/"+url.PathEscape(id)+"/", id, opts)
+}
+
+// GetAuthorBySlug returns an author by slug.
+func (c *ContentClient) GetAuthorBySlug(slug string, opts *GetOptions) (*Author, error) {
+	return c.GetAuthorBySlugContext(context.Background(), slug, opts)
+}
+
+// GetAuthorBySlugContext is like GetAuthorBySlug but uses ctx for the request.
+func (c *ContentClient) GetAuthorBySlugContext(ctx context.Context, slug string, opts *GetOptions) (*Author, error) {
+	return c.getAuthor(ctx, "/authors/slug/"+url.PathEscape(slug)+"/", slug, opts)
+}
+
+func (c *ContentClient) getAuthor(ctx context.Context, path, ref string, opts *GetOptions) (*Author, error) {
+	var resp AuthorsResponse
+	if err := c.get(ctx, path, opts, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Authors) == 0 {
+		return nil, fmt.Errorf("author not found: %s", ref)
+	}
+	return &resp.Authors[0], nil
+}
+
+// Tiers
+
+// ListTiers returns membership tiers matching opts. A nil opts uses the server defaults.
+func (c *ContentClient) ListTiers(opts *ListOptions) (*TiersResponse, error) {
+	return c.ListTiersContext(context.Background(), opts)
+}
+
+// ListTiersContext is like ListTiers but uses ctx for the request.
+func (c *ContentClient) ListTiersContext(ctx context.Context, opts *ListOptions) (*TiersResponse, error) {
+	var resp TiersResponse
+	if err := c.get(ctx, "/tiers/", opts, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Settings
+
+// GetSettings returns the public site settings.
+func (c *ContentClient) GetSettings() (*ContentSettings, error) {
+	return c.GetSettingsContext(context.Background())
+}
+
+// GetSettingsContext is like GetSettings but uses ctx for the request.
+func (c *ContentClient) GetSettingsContext(ctx context.Context) (*ContentSettings, error) {
+	var resp ContentSettingsResponse
+	if err := c.client.do(ctx, "GET", "/settings/", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Settings, nil
+}
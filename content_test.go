@@ -0,0 +1,273 @@
+package libecto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContentKey = "22444f78447824223cefc48062"
+
+func newContentTestServer(t *testing.T, handler http.HandlerFunc) *ContentClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, testContentKey, r.URL.Query().Get("key"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return NewContentClient(server.URL, testContentKey)
+}
+
+func TestNewContentClient(t *testing.T) {
+	client := NewContentClient("https://example.com/", testContentKey)
+	assert.Equal(t, "https://example.com/ghost/api/content", client.BaseURL())
+}
+
+func TestNewContentClient_EmptyKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer server.Close()
+
+	client := NewContentClient(server.URL, "",
+		WithTokenSource(TokenSourceFunc(func(context.Context) (string, error) { return "admin", nil })), WithRetryPolicy(DefaultRetryPolicy()))
+	_, err := client.ListPostsContext(context.Background(), nil)
+	assert.ErrorContains(t, err, "Content API key is empty")
+}
+
+func TestContentClient_ListPosts(t *testing.T) {
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ghost/api/content/posts/", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "tag:news+featured:true", q.Get("filter"))
+		assert.Equal(t, "tags,authors", q.Get("include"))
+		assert.Equal(t, "id,title,url", q.Get("fields"))
+		assert.Equal(t, "published_at desc", q.Get("order"))
+		assert.Equal(t, "2", q.Get("page"))
+		assert.Equal(t, "5", q.Get("limit"))
+		w.Write([]byte(`{"posts":[{"id":"1","title":"Hello","tags":[{"slug":"news"}]}],"meta":{"pagination":{"page":2,"limit":5,"pages":3,"total":12,"next":3,"prev":1}}}`))
+	})
+
+	resp, err := client.ListPosts(&ListOptions{
+		Filter:  "tag:news+featured:true",
+		Include: []string{"tags", "authors"},
+		Fields:  []string{"id", "title", "url"},
+		Order:   "published_at desc",
+		Page:    2,
+		Limit:   5,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Posts, 1)
+	assert.Equal(t, "Hello", resp.Posts[0].Title)
+	assert.Equal(t, "news", resp.Posts[0].Tags[0].Slug)
+	require.NotNil(t, resp.Meta)
+	assert.Equal(t, 3, *resp.Meta.Pagination.Next)
+}
+
+func TestContentClient_ListPosts_NilOptions(t *testing.T) {
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key="+testContentKey, r.URL.RawQuery)
+		w.Write([]byte(`{"posts":[]}`))
+	})
+
+	resp, err := client.ListPosts(nil)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Posts)
+}
+
+func TestContentClient_GetPost(t *testing.T) {
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ghost/api/content/posts/abc/":
+			assert.Equal(t, "authors", r.URL.Query().Get("include"))
+			w.Write([]byte(`{"posts":[{"id":"abc","title":"By ID"}]}`))
+		case "/ghost/api/content/posts/slug/hello/":
+			w.Write([]byte(`{"posts":[{"id":"abc","slug":"hello"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	post, err := client.GetPost("abc", &GetOptions{Include: []string{"authors"}})
+	require.NoError(t, err)
+	assert.Equal(t, "By ID", post.Title)
+
+	post, err = client.GetPostBySlug("hello", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", post.Slug)
+}
+
+func TestContentClient_GetPost_NotFound(t *testing.T) {
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"message":"Post not found.","type":"NotFoundError"}]}`))
+	})
+
+	_, err := client.GetPost("missing", nil)
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestContentClient_Pages(t *testing.T) {
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ghost/api/content/pages/":
+			w.Write([]byte(`{"pages":[{"id":"p1","title":"About"}]}`))
+		case "/ghost/api/content/pages/slug/about/":
+			w.Write([]byte(`{"pages":[{"id":"p1","slug":"about"}]}`))
+		case "/ghost/api/content/pages/p1/":
+			w.Write([]byte(`{"pages":[]}`))
+		}
+	})
+
+	resp, err := client.ListPages(&ListOptions{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "About", resp.Pages[0].Title)
+
+	page, err := client.GetPageBySlug("about", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "p1", page.ID)
+
+	_, err = client.GetPage("p1", nil)
+	assert.EqualError(t, err, "page not found: p1")
+}
+
+func TestContentClient_Tags(t *testing.T) {
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ghost/api/content/tags/":
+			assert.Equal(t, "count.posts", r.URL.Query().Get("include"))
+			w.Write([]byte(`{"tags":[{"id":"t1","name":"News"}]}`))
+		case "/ghost/api/content/tags/t1/", "/ghost/api/content/tags/slug/news/":
+			w.Write([]byte(`{"tags":[{"id":"t1","slug":"news"}]}`))
+		}
+	})
+
+	resp, err := client.ListTags(&ListOptions{Include: []string{"count.posts"}})
+	require.NoError(t, err)
+	assert.Equal(t, "News", resp.Tags[0].Name)
+
+	tag, err := client.GetTag("t1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "news", tag.Slug)
+
+	tag, err = client.GetTagBySlug("news", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "t1", tag.ID)
+}
+
+func TestContentClient_Authors(t *testing.T) {
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ghost/api/content/authors/":
+			w.Write([]byte(`{"authors":[{"id":"a1","name":"Jane"}],"meta":{"pagination":{"page":1,"limit":15,"pages":1,"total":1}}}`))
+		case "/ghost/api/content/authors/a1/", "/ghost/api/content/authors/slug/jane/":
+			w.Write([]byte(`{"authors":[{"id":"a1","slug":"jane"}]}`))
+		}
+	})
+
+	resp, err := client.ListAuthors(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane", resp.Authors[0].Name)
+	assert.Equal(t, 1, resp.Meta.Pagination.Total)
+
+	author, err := client.GetAuthor("a1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "jane", author.Slug)
+
+	author, err = client.GetAuthorBySlug("jane", &GetOptions{Fields: []string{"id", "slug"}})
+	require.NoError(t, err)
+	assert.Equal(t, "a1", author.ID)
+}
+
+func TestContentClient_ListTiers(t *testing.T) {
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ghost/api/content/tiers/", r.URL.Path)
+		assert.Equal(t, "monthly_price,yearly_price,benefits", r.URL.Query().Get("include"))
+		w.Write([]byte(`{"tiers":[{"id":"t1","name":"Free","type":"free","active":true},{"id":"t2","name":"Gold","type":"paid","monthly_price":500,"currency":"USD","benefits":["Extras"]}]}`))
+	})
+
+	resp, err := client.ListTiers(&ListOptions{Include: []string{"monthly_price", "yearly_price", "benefits"}})
+	require.NoError(t, err)
+	require.Len(t, resp.Tiers, 2)
+	assert.Nil(t, resp.Tiers[0].MonthlyPrice)
+	assert.True(t, resp.Tiers[0].Active)
+	assert.Equal(t, 500, *resp.Tiers[1].MonthlyPrice)
+	assert.Equal(t, []string{"Extras"}, resp.Tiers[1].Benefits)
+}
+
+func TestContentClient_GetSettings(t *testing.T) {
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ghost/api/content/settings/", r.URL.Path)
+		w.Write([]byte(`{"settings":{"title":"My Blog","accent_color":"#ff0000","navigation":[{"label":"Home","url":"/"}]}}`))
+	})
+
+	settings, err := client.GetSettings()
+	require.NoError(t, err)
+	assert.Equal(t, "My Blog", settings.Title)
+	assert.Equal(t, "#ff0000", settings.AccentColor)
+	assert.Equal(t, []NavigationItem{{Label: "Home", URL: "/"}}, settings.Navigation)
+}
+
+func TestContentClient_EscapesPaths(t *testing.T) {
+	var paths []string
+	client := newContentTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		w.Write([]byte(`{"posts":[{}],"pages":[{}],"tags":[{}],"authors":[{}]}`))
+	})
+
+	_, err := client.GetPost("a/b", nil)
+	require.NoError(t, err)
+	_, err = client.GetPostBySlug("what?", nil)
+	require.NoError(t, err)
+	_, err = client.GetPage("a#b", nil)
+	require.NoError(t, err)
+	_, err = client.GetPageBySlug("a/b", nil)
+	require.NoError(t, err)
+	_, err = client.GetTag("c#", nil)
+	require.NoError(t, err)
+	_, err = client.GetTagBySlug("c++ & go", nil)
+	require.NoError(t, err)
+	_, err = client.GetAuthor("x?y", nil)
+	require.NoError(t, err)
+	_, err = client.GetAuthorBySlug("../admin", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/ghost/api/content/posts/a%2Fb/",
+		"/ghost/api/content/posts/slug/what%3F/",
+		"/ghost/api/content/pages/a%23b/",
+		"/ghost/api/content/pages/slug/a%2Fb/",
+		"/ghost/api/content/tags/c%23/",
+		"/ghost/api/content/tags/slug/c++%20&%20go/",
+		"/ghost/api/content/authors/x%3Fy/",
+		"/ghost/api/content/authors/slug/..%2Fadmin/",
+	}, paths)
+}
+
+func TestContentClient_Options(t *testing.T) {
+	var seen *Request
+	capture := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			seen = req
+			return next(ctx, req)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tags":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewContentClient(server.URL, testContentKey, WithMiddleware(capture))
+	_, err := client.ListTags(nil)
+	require.NoError(t, err)
+	require.NotNil(t, seen)
+	assert.Equal(t, "tags.list", seen.Operation())
+	assert.NotContains(t, seen.Path, testContentKey)
+}
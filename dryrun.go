@@ -0,0 +1,116 @@
+package libecto
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// PlannedCall is a mutating API call recorded by a dry-run client.
+type PlannedCall struct {
+	// Method is the HTTP method.
+	Method string `json:"method"`
+	// Path is the request path relative to the API base URL, including any query string.
+	Path string `json:"path"`
+	// Body is the JSON request body, or nil for calls without a body and for uploads.
+	Body json.RawMessage `json:"body,omitempty"`
+	// Upload is the file name of an image upload.
+	Upload string `json:"upload,omitempty"`
+	// UploadSize is the size in bytes of an image upload.
+	UploadSize int64 `json:"upload_size,omitempty"`
+}
+
+// Plan collects the calls a dry-run client would have made.
+// A Plan is safe for concurrent use.
+type Plan struct {
+	mu    sync.Mutex
+	calls []PlannedCall
+}
+
+// NewPlan creates an empty plan.
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// Calls returns the recorded calls in the order they were made.
+func (p *Plan) Calls() []PlannedCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedCall(nil), p.calls...)
+}
+
+// Reset discards all recorded calls.
+func (p *Plan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = nil
+}
+
+// MarshalJSON encodes the plan as a JSON array of calls.
+func (p *Plan) MarshalJSON() ([]byte, error) {
+	calls := p.Calls()
+	if calls == nil {
+		calls = []PlannedCall{}
+	}
+	return json.Marshal(calls)
+}
+
+func (p *Plan) add(call PlannedCall) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, call)
+}
+
+// WithDryRun records mutating calls into plan instead of sending them.
+// GET requests still reach the site, so lookups such as the one made by
+// PublishPost work normally. Create and update calls return the request body
+// as if the server had accepted it unchanged, deletes succeed with no content,
+// and image uploads return a placeholder URL of the form "dry-run:<filename>".
+func WithDryRun(plan *Plan) ClientOption {
+	return func(c *Client) {
+		c.dryRun = plan
+	}
+}
+
+// wrap returns a Handler that records non-GET requests instead of calling next.
+func (p *Plan) wrap(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		if req.Method == http.MethodGet || req.Method == http.MethodHead {
+			return next(ctx, req)
+		}
+
+		call := PlannedCall{Method: req.Method, Path: req.Path}
+		resp := &Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+		switch body := req.Body.(type) {
+		case nil:
+			resp.StatusCode = http.StatusNoContent
+		case *FileUpload:
+			n, err := io.Copy(io.Discard, body.Content)
+			if err != nil {
+				return nil, err
+			}
+			call.Upload = body.Filename
+			call.UploadSize = n
+			data, err := json.Marshal(ImagesResponse{Images: []Image{{URL: "dry-run:" + body.Filename}}})
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = data
+			resp.Header.Set("Content-Type", "application/json")
+		default:
+			data, err := json.Marshal(body)
+			if err != nil {
+				return nil, err
+			}
+			call.Body = data
+			resp.Body = data
+			resp.Header.Set("Content-Type", "application/json")
+		}
+
+		p.add(call)
+		return resp, nil
+	}
+}
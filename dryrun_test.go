@@ -0,0 +1,126 @@
+package libecto
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDryRun_RecordsMutations(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Write([]byte(`{"posts":[{"id":"p1","title":"Live","updated_at":"2024-01-01T00:00:00.000Z"}]}`))
+	}))
+	defer server.Close()
+
+	plan := NewPlan()
+	client := NewClient(server.URL, testAPIKey, WithDryRun(plan))
+
+	created, err := client.CreatePost(&Post{Title: "Draft", HTML: "<p>Hi</p>"})
+	require.NoError(t, err)
+	assert.Equal(t, "Draft", created.Title)
+
+	updated, err := client.UpdatePost("p1", &Post{Title: "Renamed", UpdatedAt: "2024-01-01T00:00:00.000Z"})
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Title)
+
+	require.NoError(t, client.DeletePost("p1"))
+	require.NoError(t, client.DeleteWebhook("w1"))
+
+	tag, err := client.CreateTag(&Tag{Name: "News"})
+	require.NoError(t, err)
+	assert.Equal(t, "News", tag.Name)
+
+	// Only GETs reach the server.
+	assert.Empty(t, methods)
+
+	calls := plan.Calls()
+	require.Len(t, calls, 5)
+	assert.Equal(t, "POST", calls[0].Method)
+	assert.True(t, strings.HasPrefix(calls[0].Path, "/posts/?"))
+	assert.JSONEq(t, `{"posts":[{"title":"Draft","html":"<p>Hi</p>"}]}`, string(calls[0].Body))
+	assert.Equal(t, "PUT", calls[1].Method)
+	assert.Contains(t, calls[1].Path, "/posts/p1/")
+	assert.Equal(t, PlannedCall{Method: "DELETE", Path: "/posts/p1/"}, calls[2])
+	assert.Equal(t, PlannedCall{Method: "DELETE", Path: "/webhooks/w1/"}, calls[3])
+	assert.Equal(t, "/tags/", calls[4].Path)
+}
+
+func TestWithDryRun_GetsPassThrough(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Write([]byte(`{"posts":[{"id":"p1","title":"Live","status":"draft","updated_at":"2024-01-01T00:00:00.000Z"}]}`))
+	}))
+	defer server.Close()
+
+	plan := NewPlan()
+	client := NewClient(server.URL, testAPIKey, WithDryRun(plan))
+
+	post, err := client.PublishPost("p1")
+	require.NoError(t, err)
+	assert.Equal(t, "published", post.Status)
+	assert.Equal(t, "2024-01-01T00:00:00.000Z", post.UpdatedAt)
+	assert.Equal(t, []string{"GET"}, methods)
+
+	calls := plan.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "PUT", calls[0].Method)
+	assert.JSONEq(t, `{"posts":[{"status":"published","updated_at":"2024-01-01T00:00:00.000Z"}]}`, string(calls[0].Body))
+}
+
+func TestWithDryRun_Upload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	plan := NewPlan()
+	client := NewClient(server.URL, testAPIKey, WithDryRun(plan))
+
+	resp, err := client.UploadImageReader(strings.NewReader("image bytes"), "photo.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "dry-run:photo.jpg", resp.Images[0].URL)
+
+	calls := plan.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, PlannedCall{Method: "POST", Path: "/images/upload/", Upload: "photo.jpg", UploadSize: 11}, calls[0])
+}
+
+func TestPlan_ResetAndJSON(t *testing.T) {
+	plan := NewPlan()
+	data, err := json.Marshal(plan)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+
+	client := NewClient("http://127.0.0.1:0", testAPIKey, WithDryRun(plan))
+	require.NoError(t, client.DeleteTag("t1"))
+
+	data, err = json.Marshal(plan)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"method":"DELETE","path":"/tags/t1/"}]`, string(data))
+
+	plan.Reset()
+	assert.Empty(t, plan.Calls())
+}
+
+func TestWithDryRun_DoesNotInvalidateCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"1"`)
+		w.Write([]byte(`{"tags":[]}`))
+	}))
+	defer server.Close()
+
+	cache := NewResponseCache()
+	client := NewClient(server.URL, testAPIKey, WithResponseCache(cache), WithDryRun(NewPlan()))
+	_, err := client.ListTags(0)
+	require.NoError(t, err)
+	require.NoError(t, client.DeleteTag("t1"))
+	assert.Equal(t, 1, cache.Stats().Entries)
+}
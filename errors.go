@@ -0,0 +1,133 @@
+package libecto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Ghost error types returned in APIError.Type.
+const (
+	ErrorTypeNotFound        = "NotFoundError"
+	ErrorTypeUpdateCollision = "UpdateCollisionError"
+	ErrorTypeValidation      = "ValidationError"
+	ErrorTypeUnauthorized    = "UnauthorizedError"
+	ErrorTypeNoPermission    = "NoPermissionError"
+	ErrorTypeTooManyRequests = "TooManyRequestsError"
+)
+
+// ResponseError is returned when the Ghost API responds with an error status code.
+// It preserves everything Ghost sent back so callers can inspect it with errors.As:
+//
+//	var respErr *libecto.ResponseError
+//	if errors.As(err, &respErr) {
+//		fmt.Println(respErr.StatusCode, respErr.Type())
+//	}
+type ResponseError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Method is the HTTP method of the failed request.
+	Method string
+	// Path is the request path relative to the API base URL.
+	Path string
+	// Errors contains every error entry Ghost returned, if the body was a Ghost error response.
+	Errors []APIError
+	// Body is the raw response body.
+	Body []byte
+}
+
+// newResponseError builds a ResponseError from a failed response body,
+// decoding the Ghost error envelope when present.
+func newResponseError(statusCode int, method, path string, body []byte) *ResponseError {
+	e := &ResponseError{
+		StatusCode: statusCode,
+		Method:     method,
+		Path:       path,
+		Body:       body,
+	}
+	var errResp ErrorResponse
+	if json.Unmarshal(body, &errResp) == nil {
+		e.Errors = errResp.Errors
+	}
+	return e
+}
+
+// notFoundError returns a ResponseError like the one Ghost sends for a missing resource.
+func notFoundError(method, path, message string) *ResponseError {
+	return &ResponseError{
+		StatusCode: http.StatusNotFound,
+		Method:     method,
+		Path:       path,
+		Errors:     []APIError{{Message: message, Type: ErrorTypeNotFound}},
+	}
+}
+
+// Error formats the error as "API error (status): message: context".
+// When Ghost returns several errors, their messages are joined with "; ".
+func (e *ResponseError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("API error (%d): %s", e.StatusCode, string(e.Body))
+	}
+	msgs := make([]string, 0, len(e.Errors))
+	for _, apiErr := range e.Errors {
+		msgs = append(msgs, apiErr.Error())
+	}
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, strings.Join(msgs, "; "))
+}
+
+// Type returns the Ghost error type of the first error entry, or an empty string.
+func (e *ResponseError) Type() string {
+	if len(e.Errors) == 0 {
+		return ""
+	}
+	return e.Errors[0].Type
+}
+
+// HasType reports whether any of the returned error entries has the given Ghost error type.
+func (e *ResponseError) HasType(errorType string) bool {
+	for _, apiErr := range e.Errors {
+		if apiErr.Type == errorType {
+			return true
+		}
+	}
+	return false
+}
+
+// Error returns the message, followed by the context when present.
+func (e APIError) Error() string {
+	if e.Context != "" {
+		return e.Message + ": " + e.Context
+	}
+	return e.Message
+}
+
+// IsNotFound reports whether err is a Ghost API error for a missing resource.
+func IsNotFound(err error) bool {
+	return matchResponseError(err, http.StatusNotFound, ErrorTypeNotFound)
+}
+
+// IsConflict reports whether err is a Ghost API error caused by an update collision,
+// which happens when the updated_at sent with an update is stale.
+func IsConflict(err error) bool {
+	return matchResponseError(err, http.StatusConflict, ErrorTypeUpdateCollision)
+}
+
+// IsValidation reports whether err is a Ghost API validation error.
+func IsValidation(err error) bool {
+	return matchResponseError(err, http.StatusUnprocessableEntity, ErrorTypeValidation)
+}
+
+// IsUnauthorized reports whether err is a Ghost API error caused by missing or invalid credentials.
+func IsUnauthorized(err error) bool {
+	return matchResponseError(err, http.StatusUnauthorized, ErrorTypeUnauthorized)
+}
+
+func matchResponseError(err error, statusCode int, errorType string) bool {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+	return respErr.StatusCode == statusCode || respErr.HasType(errorType)
+}
@@ -0,0 +1,144 @@
+package libecto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *ResponseError
+		want string
+	}{
+		{
+			name: "raw body",
+			err:  &ResponseError{StatusCode: 500, Body: []byte("Internal Server Error")},
+			want: "API error (500): Internal Server Error",
+		},
+		{
+			name: "single error with context",
+			err: &ResponseError{StatusCode: 422, Errors: []APIError{
+				{Message: "Validation failed", Context: "Title is required"},
+			}},
+			want: "API error (422): Validation failed: Title is required",
+		},
+		{
+			name: "multiple errors",
+			err: &ResponseError{StatusCode: 422, Errors: []APIError{
+				{Message: "Validation failed", Context: "Title is required"},
+				{Message: "Value too long"},
+			}},
+			want: "API error (422): Validation failed: Title is required; Value too long",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.Error())
+		})
+	}
+}
+
+func TestResponseError_Type(t *testing.T) {
+	err := &ResponseError{Errors: []APIError{{Type: ErrorTypeValidation}, {Type: ErrorTypeNotFound}}}
+	assert.Equal(t, ErrorTypeValidation, err.Type())
+	assert.True(t, err.HasType(ErrorTypeNotFound))
+	assert.False(t, err.HasType(ErrorTypeUpdateCollision))
+	assert.Equal(t, "", (&ResponseError{}).Type())
+}
+
+func TestNewResponseError(t *testing.T) {
+	body, _ := json.Marshal(ErrorResponse{Errors: []APIError{
+		{Message: "first", Type: ErrorTypeValidation},
+		{Message: "second", Type: ErrorTypeValidation},
+	}})
+	err := newResponseError(422, "POST", "/posts/", body)
+	assert.Equal(t, 422, err.StatusCode)
+	assert.Equal(t, "POST", err.Method)
+	assert.Equal(t, "/posts/", err.Path)
+	assert.Len(t, err.Errors, 2)
+	assert.Equal(t, body, err.Body)
+
+	err = newResponseError(502, "GET", "/posts/", []byte("<html>Bad Gateway</html>"))
+	assert.Empty(t, err.Errors)
+}
+
+func TestErrorHelpers(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		check  func(error) bool
+		expect bool
+	}{
+		{"not found by status", &ResponseError{StatusCode: 404}, IsNotFound, true},
+		{"not found by type", &ResponseError{StatusCode: 400, Errors: []APIError{{Type: ErrorTypeNotFound}}}, IsNotFound, true},
+		{"not found wrapped", fmt.Errorf("lookup: %w", &ResponseError{StatusCode: 404}), IsNotFound, true},
+		{"not found other status", &ResponseError{StatusCode: 500}, IsNotFound, false},
+		{"not found plain error", errors.New("404"), IsNotFound, false},
+		{"conflict by status", &ResponseError{StatusCode: 409}, IsConflict, true},
+		{"conflict by type", &ResponseError{StatusCode: 400, Errors: []APIError{{Type: ErrorTypeUpdateCollision}}}, IsConflict, true},
+		{"validation by status", &ResponseError{StatusCode: 422}, IsValidation, true},
+		{"validation by type", &ResponseError{StatusCode: 400, Errors: []APIError{{Type: ErrorTypeValidation}}}, IsValidation, true},
+		{"unauthorized by status", &ResponseError{StatusCode: 401}, IsUnauthorized, true},
+		{"unauthorized by type", &ResponseError{StatusCode: 403, Errors: []APIError{{Type: ErrorTypeUnauthorized}}}, IsUnauthorized, true},
+		{"unauthorized nil", nil, IsUnauthorized, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, tt.check(tt.err))
+		})
+	}
+}
+
+func TestClient_ResponseError(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{
+			Message: "Saving failed! Someone else is editing this post.",
+			Type:    ErrorTypeUpdateCollision,
+		}}})
+	})
+	defer server.Close()
+
+	_, err := client.UpdatePost("123", &Post{Title: "Stale"})
+	require.Error(t, err)
+	assert.True(t, IsConflict(err))
+
+	var respErr *ResponseError
+	require.True(t, errors.As(err, &respErr))
+	assert.Equal(t, http.StatusConflict, respErr.StatusCode)
+	assert.Equal(t, "PUT", respErr.Method)
+	assert.True(t, strings.HasPrefix(respErr.Path, "/posts/123/"))
+	assert.Equal(t, ErrorTypeUpdateCollision, respErr.Type())
+	assert.NotEmpty(t, respErr.Body)
+}
+
+func TestClient_UploadImageReader_ResponseError(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{
+			Message: "Please select a valid image.",
+			Type:    ErrorTypeValidation,
+		}}})
+	})
+	defer server.Close()
+
+	_, err := client.UploadImageReader(strings.NewReader("data"), "test.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upload failed")
+	assert.True(t, IsValidation(err))
+
+	var respErr *ResponseError
+	require.True(t, errors.As(err, &respErr))
+	assert.Equal(t, "POST", respErr.Method)
+	assert.Equal(t, "/images/upload/", respErr.Path)
+}
@@ -0,0 +1,303 @@
+package libecto
+
+import "context"
+
+// Iterator walks every item of a paginated list endpoint, fetching further
+// pages as needed by following Pagination.Next:
+//
+//	it := client.IteratePosts(ctx, &libecto.ListOptions{Filter: "status:published"})
+//	defer it.Close()
+//	for it.Next() {
+//		post := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// An Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	fetch  pageFetcher[T]
+	opts   ListOptions
+	ahead  int
+
+	items   []T
+	value   T
+	meta    *Meta
+	next    *int // next page to fetch sequentially, or nil when done
+	started bool
+	err     error
+
+	// pending delivers prefetched pages in order when ahead > 0.
+	pending chan chan pageResult[T]
+}
+
+// pageFetcher fetches one page of a list endpoint.
+type pageFetcher[T any] func(ctx context.Context, opts *ListOptions) ([]T, *Meta, error)
+
+type pageResult[T any] struct {
+	items []T
+	meta  *Meta
+	err   error
+}
+
+// IteratorOption configures an Iterator.
+type IteratorOption func(*iteratorConfig)
+
+type iteratorConfig struct {
+	prefetch int
+}
+
+// Prefetch makes the iterator fetch up to n pages ahead of the one being
+// consumed, concurrently. Prefetching starts once the first page reports the
+// total number of pages. Zero, the default, fetches pages one at a time.
+func Prefetch(n int) IteratorOption {
+	return func(c *iteratorConfig) {
+		c.prefetch = n
+	}
+}
+
+// newIterator returns an Iterator over the pages fetch returns for opts.
+// opts is copied, so the caller may reuse it.
+func newIterator[T any](ctx context.Context, opts *ListOptions, fetch pageFetcher[T], iterOpts []IteratorOption) *Iterator[T] {
+	var cfg iteratorConfig
+	for _, opt := range iterOpts {
+		opt(&cfg)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	it := &Iterator[T]{ctx: ctx, cancel: cancel, fetch: fetch, ahead: cfg.prefetch}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
+
+// Next advances to the next item, fetching the next page if the current one
+// is exhausted. It returns false when there are no more items or an error
+// occurred; check Err to distinguish the two.
+func (it *Iterator[T]) Next() bool {
+	for len(it.items) == 0 {
+		if it.err != nil || !it.nextPage() {
+			var zero T
+			it.value = zero
+			it.Close()
+			return false
+		}
+	}
+	it.value = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Meta returns the pagination metadata of the most recently fetched page,
+// or nil before the first page is fetched.
+func (it *Iterator[T]) Meta() *Meta {
+	return it.meta
+}
+
+// Close stops the iteration and cancels any prefetches in flight.
+// It is called automatically when Next returns false.
+func (it *Iterator[T]) Close() {
+	it.cancel()
+	if it.pending != nil {
+		// Wait for the producer to stop so no requests outlive the iterator.
+		for ch := range it.pending {
+			<-ch
+		}
+		it.pending = nil
+	}
+}
+
+// nextPage loads the next page into it.items. It returns false when there
+// are no more pages or the fetch failed.
+func (it *Iterator[T]) nextPage() bool {
+	var res pageResult[T]
+	switch {
+	case !it.started:
+		it.started = true
+		opts := it.opts
+		res.items, res.meta, res.err = it.fetch(it.ctx, &opts)
+		if res.err == nil && it.ahead > 0 {
+			it.startPrefetch(res.meta)
+		}
+	case it.pending != nil:
+		ch, ok := <-it.pending
+		if !ok {
+			return false
+		}
+		res = <-ch
+	case it.next != nil:
+		opts := it.opts
+		opts.Page = *it.next
+		res.items, res.meta, res.err = it.fetch(it.ctx, &opts)
+	default:
+		return false
+	}
+
+	if res.err != nil {
+		it.err = res.err
+		return false
+	}
+	it.items, it.meta, it.next = res.items, res.meta, nil
+	if it.pending == nil && res.meta != nil && res.meta.Pagination.Next != nil && len(res.items) > 0 {
+		next := *res.meta.Pagination.Next
+		it.next = &next
+	}
+	return true
+}
+
+// startPrefetch schedules the remaining pages reported by the first page's
+// metadata, keeping at most it.ahead of them queued ahead of the consumer.
+func (it *Iterator[T]) startPrefetch(meta *Meta) {
+	if meta == nil || meta.Pagination.Next == nil {
+		return
+	}
+	first, last := *meta.Pagination.Next, meta.Pagination.Pages
+	pending := make(chan chan pageResult[T], it.ahead)
+	it.pending = pending
+	go func() {
+		defer close(pending)
+		for page := first; page <= last; page++ {
+			ch := make(chan pageResult[T], 1)
+			select {
+			case pending <- ch:
+			case <-it.ctx.Done():
+				return
+			}
+			opts := it.opts
+			opts.Page = page
+			go func() {
+				var res pageResult[T]
+				res.items, res.meta, res.err = it.fetch(it.ctx, &opts)
+				ch <- res
+			}()
+		}
+	}()
+}
+
+// Admin API iterators
+
+// IteratePosts returns an Iterator over all posts matching opts.
+// opts.Page sets the first page; opts.Limit sets the page size.
+func (c *Client) IteratePosts(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Post] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Post, *Meta, error) {
+		resp, err := c.ListPostsWithOptions(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Posts, resp.Meta, nil
+	}, iterOpts)
+}
+
+// IteratePages returns an Iterator over all pages matching opts.
+func (c *Client) IteratePages(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Page] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Page, *Meta, error) {
+		resp, err := c.ListPagesWithOptions(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Pages, resp.Meta, nil
+	}, iterOpts)
+}
+
+// IterateTags returns an Iterator over all tags matching opts.
+func (c *Client) IterateTags(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Tag] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Tag, *Meta, error) {
+		resp, err := c.ListTagsWithOptions(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Tags, resp.Meta, nil
+	}, iterOpts)
+}
+
+// IterateUsers returns an Iterator over all users matching opts.
+func (c *Client) IterateUsers(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Author] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Author, *Meta, error) {
+		resp, err := c.ListUsersWithOptions(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Users, resp.Meta, nil
+	}, iterOpts)
+}
+
+// IterateMembers returns an Iterator over all members matching opts.
+func (c *Client) IterateMembers(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Member] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Member, *Meta, error) {
+		resp, err := c.ListMembersWithOptions(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Members, resp.Meta, nil
+	}, iterOpts)
+}
+
+// IterateNewsletters returns an Iterator over all newsletters matching opts.
+func (c *Client) IterateNewsletters(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Newsletter] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Newsletter, *Meta, error) {
+		resp, err := c.ListNewslettersWithOptions(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Newsletters, resp.Meta, nil
+	}, iterOpts)
+}
+
+// Content API iterators
+
+// IteratePosts returns an Iterator over all published posts matching opts.
+func (c *ContentClient) IteratePosts(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Post] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Post, *Meta, error) {
+		resp, err := c.ListPostsContext(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Posts, resp.Meta, nil
+	}, iterOpts)
+}
+
+// IteratePages returns an Iterator over all published pages matching opts.
+func (c *ContentClient) IteratePages(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Page] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Page, *Meta, error) {
+		resp, err := c.ListPagesContext(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Pages, resp.Meta, nil
+	}, iterOpts)
+}
+
+// IterateTags returns an Iterator over all public tags matching opts.
+func (c *ContentClient) IterateTags(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Tag] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Tag, *Meta, error) {
+		resp, err := c.ListTagsContext(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Tags, resp.Meta, nil
+	}, iterOpts)
+}
+
+// IterateAuthors returns an Iterator over all authors matching opts.
+func (c *ContentClient) IterateAuthors(ctx context.Context, opts *ListOptions, iterOpts ...IteratorOption) *Iterator[Author] {
+	return newIterator(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]Author, *Meta, error) {
+		resp, err := c.ListAuthorsContext(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Authors, resp.Meta, nil
+	}, iterOpts)
+}
@@ -0,0 +1,224 @@
+package libecto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedServer serves total items of resource in pages of perPage, recording
+// the pages requested and the peak number of concurrent requests.
+type pagedServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	pages    []int
+	inFlight atomic.Int32
+	peak     atomic.Int32
+	delay    time.Duration
+	failPage int
+}
+
+func newPagedServer(t *testing.T, resource string, total, perPage int) *pagedServer {
+	s := &pagedServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+		for {
+			peak := s.peak.Load()
+			if n <= peak || s.peak.CompareAndSwap(peak, n) {
+				break
+			}
+		}
+		time.Sleep(s.delay)
+
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+		s.mu.Lock()
+		s.pages = append(s.pages, page)
+		s.mu.Unlock()
+		if page == s.failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		pages := (total + perPage - 1) / perPage
+		var items []map[string]string
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			items = append(items, map[string]string{"id": strconv.Itoa(i)})
+		}
+		pagination := Pagination{Page: page, Limit: perPage, Pages: pages, Total: total}
+		if page < pages {
+			next := page + 1
+			pagination.Next = &next
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			resource: items,
+			"meta":   Meta{Pagination: pagination},
+		})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *pagedServer) requested() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.pages...)
+}
+
+func collectIDs[T any](t *testing.T, it *Iterator[T], id func(T) string) []string {
+	t.Helper()
+	defer it.Close()
+	var ids []string
+	for it.Next() {
+		ids = append(ids, id(it.Value()))
+	}
+	require.NoError(t, it.Err())
+	return ids
+}
+
+func wantIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	return ids
+}
+
+func TestIterator_Sequential(t *testing.T) {
+	server := newPagedServer(t, "posts", 23, 5)
+	client := NewClient(server.URL, testAPIKey)
+
+	it := client.IteratePosts(context.Background(), &ListOptions{Limit: 5, Filter: "status:published"})
+	ids := collectIDs(t, it, func(p Post) string { return p.ID })
+	assert.Equal(t, wantIDs(23), ids)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, server.requested())
+	assert.Equal(t, int32(1), server.peak.Load())
+	assert.Equal(t, 5, it.Meta().Pagination.Page)
+}
+
+func TestIterator_StartPage(t *testing.T) {
+	server := newPagedServer(t, "tags", 10, 3)
+	client := NewClient(server.URL, testAPIKey)
+
+	opts := &ListOptions{Page: 3, Limit: 3}
+	ids := collectIDs(t, client.IterateTags(context.Background(), opts), func(tag Tag) string { return tag.ID })
+	assert.Equal(t, []string{"6", "7", "8", "9"}, ids)
+	assert.Equal(t, 3, opts.Page, "caller's options are not modified")
+}
+
+func TestIterator_Empty(t *testing.T) {
+	server := newPagedServer(t, "members", 0, 15)
+	client := NewClient(server.URL, testAPIKey)
+
+	it := client.IterateMembers(context.Background(), nil)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.False(t, it.Next())
+}
+
+func TestIterator_Prefetch(t *testing.T) {
+	server := newPagedServer(t, "posts", 50, 5)
+	server.delay = 20 * time.Millisecond
+	client := NewClient(server.URL, testAPIKey)
+
+	it := client.IteratePosts(context.Background(), &ListOptions{Limit: 5}, Prefetch(3))
+	ids := collectIDs(t, it, func(p Post) string { return p.ID })
+	assert.Equal(t, wantIDs(50), ids, "items are delivered in page order")
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, server.requested())
+	assert.Greater(t, server.peak.Load(), int32(1))
+	assert.LessOrEqual(t, server.peak.Load(), int32(4))
+}
+
+func TestIterator_Error(t *testing.T) {
+	for _, prefetch := range []int{0, 2} {
+		t.Run(fmt.Sprintf("prefetch %d", prefetch), func(t *testing.T) {
+			server := newPagedServer(t, "users", 20, 5)
+			server.failPage = 3
+			client := NewClient(server.URL, testAPIKey)
+
+			it := client.IterateUsers(context.Background(), &ListOptions{Limit: 5}, Prefetch(prefetch))
+			defer it.Close()
+			count := 0
+			for it.Next() {
+				count++
+			}
+			assert.Equal(t, 10, count)
+			var respErr *ResponseError
+			require.ErrorAs(t, it.Err(), &respErr)
+			assert.Equal(t, http.StatusInternalServerError, respErr.StatusCode)
+			assert.False(t, it.Next())
+		})
+	}
+}
+
+func TestIterator_CloseStopsPrefetch(t *testing.T) {
+	server := newPagedServer(t, "posts", 100, 1)
+	server.delay = 5 * time.Millisecond
+	client := NewClient(server.URL, testAPIKey)
+
+	it := client.IteratePosts(context.Background(), &ListOptions{Limit: 1}, Prefetch(2))
+	require.True(t, it.Next())
+	it.Close()
+	it.Close()
+
+	requested := len(server.requested())
+	assert.Less(t, requested, 10)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, requested, len(server.requested()), "no requests after Close")
+}
+
+func TestIterator_ContextCanceled(t *testing.T) {
+	server := newPagedServer(t, "pages", 10, 2)
+	client := NewClient(server.URL, testAPIKey)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := client.IteratePages(ctx, &ListOptions{Limit: 2})
+	defer it.Close()
+	require.True(t, it.Next())
+	require.True(t, it.Next())
+	cancel()
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
+
+func TestContentClient_Iterate(t *testing.T) {
+	server := newPagedServer(t, "authors", 7, 3)
+	client := NewContentClient(server.URL, testContentKey)
+
+	ids := collectIDs(t, client.IterateAuthors(context.Background(), &ListOptions{Limit: 3}, Prefetch(1)),
+		func(a Author) string { return a.ID })
+	assert.Equal(t, wantIDs(7), ids)
+}
+
+func TestClient_ListMembers(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ghost/api/admin/members/", r.URL.Path)
+		assert.Equal(t, "status:paid", r.URL.Query().Get("filter"))
+		w.Write([]byte(`{"members":[{"id":"m1","email":"a@example.com","status":"paid","labels":[{"name":"VIP"}],"email_open_rate":40}],
+			"meta":{"pagination":{"page":1,"limit":15,"pages":1,"total":1,"next":null,"prev":null}}}`))
+	})
+	defer server.Close()
+
+	resp, err := client.ListMembersWithOptions(context.Background(), &ListOptions{Filter: "status:paid"})
+	require.NoError(t, err)
+	require.Len(t, resp.Members, 1)
+	m := resp.Members[0]
+	assert.Equal(t, "a@example.com", m.Email)
+	assert.Equal(t, "VIP", m.Labels[0].Name)
+	require.NotNil(t, m.EmailOpenRate)
+	assert.Equal(t, 40, *m.EmailOpenRate)
+	assert.Equal(t, 1, resp.Meta.Pagination.Total)
+}
@@ -0,0 +1,77 @@
+package libecto
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/visionik/libecto/lexical"
+)
+
+// SetLexical sets the post content to doc. HTML and Mobiledoc are cleared so
+// Ghost stores the document as is rather than converting HTML.
+func (p *Post) SetLexical(doc *lexical.Document) error {
+	s, err := encodeLexical(doc)
+	if err != nil {
+		return err
+	}
+	p.Lexical, p.HTML, p.Mobiledoc = s, "", ""
+	return nil
+}
+
+// LexicalDocument parses the post's Lexical content. It returns an empty
+// document if the post has none. GetPost returns Lexical content by default;
+// list calls return it when FormatLexical is requested.
+func (p *Post) LexicalDocument() (*lexical.Document, error) {
+	return decodeLexical(p.Lexical)
+}
+
+// SetLexical sets the page content to doc. HTML and Mobiledoc are cleared so
+// Ghost stores the document as is rather than converting HTML.
+func (p *Page) SetLexical(doc *lexical.Document) error {
+	s, err := encodeLexical(doc)
+	if err != nil {
+		return err
+	}
+	p.Lexical, p.HTML, p.Mobiledoc = s, "", ""
+	return nil
+}
+
+// LexicalDocument parses the page's Lexical content. It returns an empty
+// document if the page has none.
+func (p *Page) LexicalDocument() (*lexical.Document, error) {
+	return decodeLexical(p.Lexical)
+}
+
+func encodeLexical(doc *lexical.Document) (string, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode lexical: %w", err)
+	}
+	return string(data), nil
+}
+
+func decodeLexical(s string) (*lexical.Document, error) {
+	if s == "" {
+		return lexical.New(), nil
+	}
+	doc, err := lexical.ParseString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode lexical: %w", err)
+	}
+	return doc, nil
+}
+
+// writePath returns path with the query for creating or updating content.
+// source=html asks Ghost to convert HTML into Lexical, which loses cards and
+// formatting it cannot map, so it is only sent when HTML is the sole content.
+// Posts and pages fetched with the editQuery defaults carry their Lexical or
+// Mobiledoc content, so updating them never sends it.
+func writePath(path, html, lexical, mobiledoc string) string {
+	v := url.Values{}
+	if html != "" && lexical == "" && mobiledoc == "" {
+		v.Set("source", "html")
+	}
+	v.Set("formats", FormatHTML)
+	return withQuery(path, v)
+}
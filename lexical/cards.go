@@ -0,0 +1,262 @@
+package lexical
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Cards are the block-level decorator nodes of the Ghost editor. Captions and
+// other rich fields hold HTML.
+
+// Card widths for images and embeds.
+const (
+	CardWidthRegular = "regular"
+	CardWidthWide    = "wide"
+	CardWidthFull    = "full"
+)
+
+// Image is an image card.
+type Image struct {
+	Src     string `json:"src"`
+	Width   int    `json:"width,omitempty"`
+	Height  int    `json:"height,omitempty"`
+	Title   string `json:"title"`
+	Alt     string `json:"alt"`
+	Caption string `json:"caption"`
+	// CardWidth is CardWidthRegular, CardWidthWide or CardWidthFull.
+	CardWidth string `json:"cardWidth"`
+	// Href makes the image a link.
+	Href string `json:"href"`
+}
+
+// NewImage returns a regular-width image card.
+func NewImage(src, alt, caption string) *Image {
+	return &Image{Src: src, Alt: alt, Caption: caption, CardWidth: CardWidthRegular}
+}
+
+// Type returns "image".
+func (*Image) Type() string { return "image" }
+
+// MarshalJSON encodes the image card.
+func (c *Image) MarshalJSON() ([]byte, error) {
+	type image Image
+	return marshalCard("image", (*image)(c))
+}
+
+// GalleryImage is one image of a Gallery.
+type GalleryImage struct {
+	// Row is the gallery row, 0-based. Ghost shows up to three images a row.
+	Row      int    `json:"row"`
+	Src      string `json:"src"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Alt      string `json:"alt,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+}
+
+// Gallery is a gallery card of up to nine images.
+type Gallery struct {
+	Images  []GalleryImage `json:"images"`
+	Caption string         `json:"caption"`
+}
+
+// NewGallery returns a gallery card, placing the images three to a row.
+func NewGallery(images ...GalleryImage) *Gallery {
+	g := &Gallery{Images: make([]GalleryImage, len(images))}
+	for i, img := range images {
+		img.Row = i / 3
+		g.Images[i] = img
+	}
+	return g
+}
+
+// Type returns "gallery".
+func (*Gallery) Type() string { return "gallery" }
+
+// MarshalJSON encodes the gallery card.
+func (c *Gallery) MarshalJSON() ([]byte, error) {
+	type gallery Gallery
+	g := *(*gallery)(c)
+	if g.Images == nil {
+		g.Images = []GalleryImage{}
+	}
+	return marshalCard("gallery", &g)
+}
+
+// CodeBlock is a code card.
+type CodeBlock struct {
+	Code     string `json:"code"`
+	Language string `json:"language"`
+	Caption  string `json:"caption"`
+}
+
+// NewCodeBlock returns a code card; language may be empty.
+func NewCodeBlock(code, language string) *CodeBlock {
+	return &CodeBlock{Code: code, Language: language}
+}
+
+// Type returns "codeblock".
+func (*CodeBlock) Type() string { return "codeblock" }
+
+// MarshalJSON encodes the code card.
+func (c *CodeBlock) MarshalJSON() ([]byte, error) {
+	type codeBlock CodeBlock
+	return marshalCard("codeblock", (*codeBlock)(c))
+}
+
+// Callout is a highlighted callout card.
+type Callout struct {
+	// Text is the callout's HTML content.
+	Text  string `json:"calloutText"`
+	Emoji string `json:"calloutEmoji"`
+	// BackgroundColor is a Ghost color name such as "grey", "blue" or "accent".
+	BackgroundColor string `json:"backgroundColor"`
+}
+
+// NewCallout returns a grey callout card with the given HTML and emoji.
+func NewCallout(html, emoji string) *Callout {
+	return &Callout{Text: html, Emoji: emoji, BackgroundColor: "grey"}
+}
+
+// Type returns "callout".
+func (*Callout) Type() string { return "callout" }
+
+// MarshalJSON encodes the callout card.
+func (c *Callout) MarshalJSON() ([]byte, error) {
+	type callout Callout
+	return marshalCard("callout", (*callout)(c))
+}
+
+// BookmarkMetadata describes the page a Bookmark points to.
+type BookmarkMetadata struct {
+	URL         string `json:"url,omitempty"`
+	Icon        string `json:"icon,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Author      string `json:"author,omitempty"`
+	Publisher   string `json:"publisher,omitempty"`
+	Thumbnail   string `json:"thumbnail,omitempty"`
+}
+
+// Bookmark is a link preview card.
+type Bookmark struct {
+	URL      string           `json:"url"`
+	Metadata BookmarkMetadata `json:"metadata"`
+	Caption  string           `json:"caption"`
+}
+
+// NewBookmark returns a bookmark card for url.
+func NewBookmark(url string, metadata BookmarkMetadata) *Bookmark {
+	return &Bookmark{URL: url, Metadata: metadata}
+}
+
+// Type returns "bookmark".
+func (*Bookmark) Type() string { return "bookmark" }
+
+// MarshalJSON encodes the bookmark card.
+func (c *Bookmark) MarshalJSON() ([]byte, error) {
+	type bookmark Bookmark
+	return marshalCard("bookmark", (*bookmark)(c))
+}
+
+// Embed is an oEmbed card, such as a video or social media post.
+type Embed struct {
+	URL string `json:"url"`
+	// EmbedType is the oEmbed type, such as "video" or "rich".
+	EmbedType string                 `json:"embedType"`
+	HTML      string                 `json:"html"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	Caption   string                 `json:"caption"`
+}
+
+// NewEmbed returns an embed card showing html for url.
+func NewEmbed(url, html string) *Embed {
+	return &Embed{URL: url, HTML: html}
+}
+
+// Type returns "embed".
+func (*Embed) Type() string { return "embed" }
+
+// MarshalJSON encodes the embed card.
+func (c *Embed) MarshalJSON() ([]byte, error) {
+	type embed Embed
+	e := *(*embed)(c)
+	if e.Metadata == nil {
+		e.Metadata = map[string]interface{}{}
+	}
+	return marshalCard("embed", &e)
+}
+
+// HTML is a raw HTML card.
+type HTML struct {
+	HTML string `json:"html"`
+}
+
+// NewHTML returns a raw HTML card.
+func NewHTML(html string) *HTML {
+	return &HTML{HTML: html}
+}
+
+// Type returns "html".
+func (*HTML) Type() string { return "html" }
+
+// MarshalJSON encodes the HTML card.
+func (c *HTML) MarshalJSON() ([]byte, error) {
+	type htmlCard HTML
+	return marshalCard("html", (*htmlCard)(c))
+}
+
+// Markdown is a Markdown card, rendered by Ghost.
+type Markdown struct {
+	Markdown string `json:"markdown"`
+}
+
+// NewMarkdown returns a Markdown card.
+func NewMarkdown(md string) *Markdown {
+	return &Markdown{Markdown: md}
+}
+
+// Type returns "markdown".
+func (*Markdown) Type() string { return "markdown" }
+
+// MarshalJSON encodes the Markdown card.
+func (c *Markdown) MarshalJSON() ([]byte, error) {
+	type markdown Markdown
+	return marshalCard("markdown", (*markdown)(c))
+}
+
+// HorizontalRule is a divider card.
+type HorizontalRule struct{}
+
+// NewHorizontalRule returns a divider card.
+func NewHorizontalRule() *HorizontalRule {
+	return &HorizontalRule{}
+}
+
+// Type returns "horizontalrule".
+func (*HorizontalRule) Type() string { return "horizontalrule" }
+
+// MarshalJSON encodes the divider card.
+func (*HorizontalRule) MarshalJSON() ([]byte, error) {
+	return []byte(`{"type":"horizontalrule","version":1}`), nil
+}
+
+// marshalCard encodes v, a card's fields, prefixed with the card's type and version.
+func marshalCard(typ string, v interface{}) ([]byte, error) {
+	fields, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`{"type":`)
+	t, _ := json.Marshal(typ)
+	buf.Write(t)
+	buf.WriteString(`,"version":1`)
+	if body := bytes.TrimSpace(fields[1 : len(fields)-1]); len(body) > 0 {
+		buf.WriteByte(',')
+		buf.Write(body)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,212 @@
+// Package lexical models Lexical documents, the JSON content format used by
+// the Ghost 5 editor and stored in Post.Lexical.
+//
+// Documents are trees of nodes. Element nodes (paragraphs, headings, quotes,
+// lists and links) contain other nodes; text nodes carry formatted text; and
+// cards (images, code blocks, callouts, bookmarks and so on) are the
+// self-contained blocks of the Ghost editor:
+//
+//	doc := lexical.New(
+//		lexical.NewHeading(2, lexical.NewText("Release notes")),
+//		lexical.NewParagraph(
+//			lexical.NewText("Read the "),
+//			lexical.NewLink("https://example.com/docs", lexical.NewText("docs")),
+//			lexical.NewFormattedText(" now", lexical.FormatBold),
+//		),
+//		lexical.NewCodeBlock("go test ./...", "bash"),
+//	)
+//	data, err := json.Marshal(doc)
+//
+// Node types the package does not know are decoded as *Unknown and encoded
+// back unchanged, so documents written by newer Ghost versions survive a
+// round trip.
+package lexical
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Node is a node of a Lexical document.
+type Node interface {
+	// Type returns the Lexical node type, such as "paragraph" or "image".
+	Type() string
+}
+
+// Document is a Lexical document: the children of its root node.
+type Document struct {
+	// Children are the top-level block nodes.
+	Children []Node
+	// Direction is the text direction of the root, "ltr" by default.
+	Direction string
+}
+
+// New returns a document with the given top-level nodes.
+func New(children ...Node) *Document {
+	return &Document{Children: children}
+}
+
+// Append adds nodes to the end of the document.
+func (d *Document) Append(nodes ...Node) {
+	d.Children = append(d.Children, nodes...)
+}
+
+// Parse decodes a serialized Lexical document, such as the value of Post.Lexical.
+func Parse(data []byte) (*Document, error) {
+	var d Document
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ParseString is like Parse but takes a string.
+func ParseString(s string) (*Document, error) {
+	return Parse([]byte(s))
+}
+
+// MarshalJSON encodes the document with its root node.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	direction := d.Direction
+	if direction == "" {
+		direction = "ltr"
+	}
+	root := elementJSON{
+		Children:  children(d.Children),
+		Direction: &direction,
+		Type:      "root",
+		Version:   1,
+	}
+	return json.Marshal(struct {
+		Root elementJSON `json:"root"`
+	}{root})
+}
+
+// UnmarshalJSON decodes a document and all of its nodes.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	var doc struct {
+		Root *elementWire `json:"root"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Root == nil {
+		return fmt.Errorf("lexical: document has no root")
+	}
+	nodes, err := decodeNodes(doc.Root.Children)
+	if err != nil {
+		return err
+	}
+	d.Children = nodes
+	d.Direction = doc.Root.Direction
+	return nil
+}
+
+// Unknown is a node of a type this package does not model. Its JSON is kept
+// as is and written back unchanged.
+type Unknown struct {
+	// Data is the node's JSON encoding.
+	Data json.RawMessage
+	typ  string
+}
+
+// Type returns the node type recorded in Data.
+func (u *Unknown) Type() string {
+	return u.typ
+}
+
+// MarshalJSON returns Data.
+func (u *Unknown) MarshalJSON() ([]byte, error) {
+	return u.Data, nil
+}
+
+// DecodeNode decodes a single serialized node. Nodes of types the package
+// does not model are returned as *Unknown.
+func DecodeNode(data []byte) (Node, error) {
+	return decodeNode(data)
+}
+
+// decodeNodes decodes a list of serialized nodes.
+func decodeNodes(raw []json.RawMessage) ([]Node, error) {
+	nodes := make([]Node, 0, len(raw))
+	for _, r := range raw {
+		n, err := decodeNode(r)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// decodeNode decodes a single node based on its type.
+func decodeNode(raw json.RawMessage) (Node, error) {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &head); err != nil {
+		return nil, err
+	}
+
+	var n Node
+	switch head.Type {
+	case "text", "extended-text":
+		n = &Text{}
+	case "linebreak":
+		n = &LineBreak{}
+	case "tab":
+		n = &Tab{}
+	case "paragraph":
+		n = &Paragraph{}
+	case "heading", "extended-heading":
+		n = &Heading{}
+	case "quote", "extended-quote":
+		n = &Quote{}
+	case "list":
+		n = &List{}
+	case "listitem":
+		n = &ListItem{}
+	case "link":
+		n = &Link{}
+	case "image":
+		n = &Image{}
+	case "gallery":
+		n = &Gallery{}
+	case "codeblock":
+		n = &CodeBlock{}
+	case "callout":
+		n = &Callout{}
+	case "bookmark":
+		n = &Bookmark{}
+	case "embed":
+		n = &Embed{}
+	case "html":
+		n = &HTML{}
+	case "markdown":
+		n = &Markdown{}
+	case "horizontalrule":
+		n = &HorizontalRule{}
+	default:
+		return &Unknown{Data: append(json.RawMessage(nil), raw...), typ: head.Type}, nil
+	}
+	if err := json.Unmarshal(raw, n); err != nil {
+		return nil, fmt.Errorf("lexical: decoding %s node: %w", head.Type, err)
+	}
+	return n, nil
+}
+
+// children returns nodes, or an empty list so it never encodes as null.
+func children(nodes []Node) []Node {
+	if nodes == nil {
+		return []Node{}
+	}
+	return nodes
+}
+
+// nullable returns nil for empty strings, which Lexical encodes as null.
+func nullable(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
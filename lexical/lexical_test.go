@@ -0,0 +1,171 @@
+package lexical
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Marshal(t *testing.T) {
+	doc := New(
+		NewHeading(2, NewText("Hello")),
+		NewParagraph(
+			NewText("Read "),
+			NewLink("https://example.com", NewFormattedText("this", FormatBold|FormatItalic)),
+			&LineBreak{},
+		),
+	)
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"root":{"children":[
+		{"children":[{"detail":0,"format":0,"mode":"normal","style":"","text":"Hello","type":"text","version":1}],
+		 "direction":"ltr","format":"","indent":0,"type":"heading","version":1,"tag":"h2"},
+		{"children":[
+			{"detail":0,"format":0,"mode":"normal","style":"","text":"Read ","type":"text","version":1},
+			{"children":[{"detail":0,"format":3,"mode":"normal","style":"","text":"this","type":"text","version":1}],
+			 "direction":"ltr","format":"","indent":0,"type":"link","version":1,
+			 "rel":null,"target":null,"title":null,"url":"https://example.com"},
+			{"type":"linebreak","version":1}],
+		 "direction":"ltr","format":"","indent":0,"type":"paragraph","version":1}],
+		"direction":"ltr","format":"","indent":0,"type":"root","version":1}}`, string(data))
+}
+
+func TestDocument_MarshalEmpty(t *testing.T) {
+	data, err := json.Marshal(New())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"root":{"children":[],"direction":"ltr","format":"","indent":0,"type":"root","version":1}}`, string(data))
+}
+
+func TestNewList(t *testing.T) {
+	list := NewNumberedList(NewListItem(NewText("one")), NewListItem(NewText("two")))
+	assert.Equal(t, "ol", list.Tag())
+	require.Len(t, list.Children, 2)
+	assert.Equal(t, 2, list.Children[1].(*ListItem).Value)
+
+	data, err := json.Marshal(list)
+	require.NoError(t, err)
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, "number", got["listType"])
+	assert.Equal(t, "ol", got["tag"])
+	assert.Equal(t, float64(1), got["start"])
+
+	assert.Equal(t, "ul", NewBulletList().Tag())
+}
+
+func TestNewHeading(t *testing.T) {
+	assert.Equal(t, "h1", NewHeading(0).Tag)
+	assert.Equal(t, "h6", NewHeading(9).Tag)
+	assert.Equal(t, 3, NewHeading(3).Level())
+	assert.Equal(t, 0, (&Heading{Tag: "p"}).Level())
+}
+
+func TestNewGallery(t *testing.T) {
+	g := NewGallery(GalleryImage{Src: "1"}, GalleryImage{Src: "2"}, GalleryImage{Src: "3"}, GalleryImage{Src: "4"})
+	rows := []int{}
+	for _, img := range g.Images {
+		rows = append(rows, img.Row)
+	}
+	assert.Equal(t, []int{0, 0, 0, 1}, rows)
+}
+
+func TestParse_GhostDocument(t *testing.T) {
+	// A document as saved by the Ghost editor, with extended nodes, numeric
+	// alignment and a node type this package does not model.
+	src := `{"root":{"children":[
+		{"children":[{"detail":0,"format":2,"mode":"normal","style":"","text":"Title","type":"extended-text","version":1}],
+		 "direction":"ltr","format":"","indent":0,"type":"extended-heading","version":1,"tag":"h1"},
+		{"children":[
+			{"children":[{"children":[{"text":"done","type":"extended-text","format":0}],"direction":"ltr","format":"","indent":0,"type":"listitem","version":1,"value":1,"checked":true}],
+			 "direction":"ltr","format":"","indent":0,"type":"list","version":1,"listType":"check","start":1,"tag":"ul"}],
+		 "direction":null,"format":0,"indent":0,"type":"paragraph","version":1},
+		{"type":"signup","version":1,"header":"Join"}
+	],"direction":"ltr","format":"","indent":0,"type":"root","version":1}}`
+
+	doc, err := ParseString(src)
+	require.NoError(t, err)
+	require.Len(t, doc.Children, 3)
+
+	h := doc.Children[0].(*Heading)
+	assert.Equal(t, 1, h.Level())
+	text := h.Children[0].(*Text)
+	assert.Equal(t, "Title", text.Text)
+	assert.True(t, text.Format.Has(FormatItalic))
+	assert.False(t, text.Format.Has(FormatBold))
+
+	p := doc.Children[1].(*Paragraph)
+	assert.Empty(t, p.Direction)
+	assert.Empty(t, p.Align)
+	list := p.Children[0].(*List)
+	assert.Equal(t, ListCheck, list.ListType)
+	item := list.Children[0].(*ListItem)
+	require.NotNil(t, item.Checked)
+	assert.True(t, *item.Checked)
+
+	unknown := doc.Children[2].(*Unknown)
+	assert.Equal(t, "signup", unknown.Type())
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	again, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, doc, again)
+	assert.Contains(t, string(data), `{"type":"signup","version":1,"header":"Join"}`)
+}
+
+func TestCards_RoundTrip(t *testing.T) {
+	checked := false
+	doc := New(
+		NewImage("https://example.com/a.png", "An image", "<em>Caption</em>"),
+		&Image{Src: "b.png", Width: 800, Height: 600, CardWidth: CardWidthWide, Href: "https://example.com"},
+		NewGallery(GalleryImage{Src: "1.png", Width: 10, Height: 10}, GalleryImage{Src: "2.png"}),
+		NewCodeBlock("fmt.Println(1)", "go"),
+		NewCallout("<p>Note</p>", "💡"),
+		NewBookmark("https://ghost.org", BookmarkMetadata{Title: "Ghost", Publisher: "Ghost"}),
+		&Embed{URL: "https://youtu.be/x", EmbedType: "video", HTML: "<iframe></iframe>",
+			Metadata: map[string]interface{}{"provider_name": "YouTube"}},
+		NewHTML("<div>raw</div>"),
+		NewMarkdown("**bold**"),
+		NewHorizontalRule(),
+		NewQuote(NewText("quoted")),
+		&List{ListType: ListCheck, Start: 1, Element: Element{Children: []Node{
+			&ListItem{Value: 1, Checked: &checked, Element: Element{Children: []Node{NewText("todo")}}},
+		}}},
+		NewParagraph(&Tab{}, &Text{Text: "x", Style: "color: red", Mode: "token", Detail: 1}),
+	)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	got, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, doc.Children, got.Children)
+
+	var raw struct {
+		Root struct {
+			Children []map[string]interface{} `json:"children"`
+		} `json:"root"`
+	}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	types := []string{}
+	for _, c := range raw.Root.Children {
+		types = append(types, c["type"].(string))
+		assert.Equal(t, float64(1), c["version"])
+	}
+	assert.Equal(t, []string{"image", "image", "gallery", "codeblock", "callout", "bookmark", "embed",
+		"html", "markdown", "horizontalrule", "quote", "list", "paragraph"}, types)
+	assert.Equal(t, "💡", raw.Root.Children[4]["calloutEmoji"])
+	assert.Equal(t, "video", raw.Root.Children[6]["embedType"])
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := ParseString(`{}`)
+	assert.ErrorContains(t, err, "no root")
+
+	_, err = ParseString(`{"root":{"children":[{"type":"heading","children":"nope"}]}}`)
+	assert.ErrorContains(t, err, "decoding heading node")
+
+	_, err = ParseString(`not json`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,441 @@
+package lexical
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TextFormat is a bit set of inline text styles.
+type TextFormat int
+
+// Text format bits, matching Lexical's encoding.
+const (
+	FormatBold TextFormat = 1 << iota
+	FormatItalic
+	FormatStrikethrough
+	FormatUnderline
+	FormatCode
+	FormatSubscript
+	FormatSuperscript
+	FormatHighlight
+)
+
+// Has reports whether all bits of f2 are set in f.
+func (f TextFormat) Has(f2 TextFormat) bool {
+	return f&f2 == f2
+}
+
+// List types.
+const (
+	ListBullet = "bullet"
+	ListNumber = "number"
+	ListCheck  = "check"
+)
+
+// Text is a run of text with a single format.
+type Text struct {
+	Text   string
+	Format TextFormat
+	// Style is inline CSS, usually empty.
+	Style string
+	// Mode is "normal", "token" or "segmented"; empty means "normal".
+	Mode   string
+	Detail int
+}
+
+// NewText returns an unformatted text node.
+func NewText(text string) *Text {
+	return &Text{Text: text}
+}
+
+// NewFormattedText returns a text node with the given format bits.
+func NewFormattedText(text string, format TextFormat) *Text {
+	return &Text{Text: text, Format: format}
+}
+
+// Type returns "text".
+func (t *Text) Type() string { return "text" }
+
+type textJSON struct {
+	Detail  int        `json:"detail"`
+	Format  TextFormat `json:"format"`
+	Mode    string     `json:"mode"`
+	Style   string     `json:"style"`
+	Text    string     `json:"text"`
+	Type    string     `json:"type"`
+	Version int        `json:"version"`
+}
+
+// MarshalJSON encodes the text node.
+func (t *Text) MarshalJSON() ([]byte, error) {
+	mode := t.Mode
+	if mode == "" {
+		mode = "normal"
+	}
+	return json.Marshal(textJSON{
+		Detail:  t.Detail,
+		Format:  t.Format,
+		Mode:    mode,
+		Style:   t.Style,
+		Text:    t.Text,
+		Type:    "text",
+		Version: 1,
+	})
+}
+
+// UnmarshalJSON decodes a text node.
+func (t *Text) UnmarshalJSON(data []byte) error {
+	var w textJSON
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	*t = Text{Text: w.Text, Format: w.Format, Style: w.Style, Mode: w.Mode, Detail: w.Detail}
+	if t.Mode == "normal" {
+		t.Mode = ""
+	}
+	return nil
+}
+
+// LineBreak is a soft line break within a block.
+type LineBreak struct{}
+
+// Type returns "linebreak".
+func (*LineBreak) Type() string { return "linebreak" }
+
+// MarshalJSON encodes the line break.
+func (*LineBreak) MarshalJSON() ([]byte, error) {
+	return []byte(`{"type":"linebreak","version":1}`), nil
+}
+
+// UnmarshalJSON accepts any line break node.
+func (*LineBreak) UnmarshalJSON([]byte) error { return nil }
+
+// Tab is a tab character within a block.
+type Tab struct{}
+
+// Type returns "tab".
+func (*Tab) Type() string { return "tab" }
+
+// MarshalJSON encodes the tab.
+func (*Tab) MarshalJSON() ([]byte, error) {
+	return []byte(`{"detail":2,"format":0,"mode":"normal","style":"","text":"\t","type":"tab","version":1}`), nil
+}
+
+// UnmarshalJSON accepts any tab node.
+func (*Tab) UnmarshalJSON([]byte) error { return nil }
+
+// Element holds the fields shared by nodes that contain other nodes.
+type Element struct {
+	Children []Node
+	// Direction is the text direction, "ltr" or "rtl"; empty encodes as null.
+	Direction string
+	// Align is the block alignment: "", "left", "center", "right" or "justify".
+	Align  string
+	Indent int
+}
+
+// Append adds nodes to the end of the element.
+func (e *Element) Append(nodes ...Node) {
+	e.Children = append(e.Children, nodes...)
+}
+
+// elementJSON is the encoding of Element shared by all element nodes.
+type elementJSON struct {
+	Children  []Node  `json:"children"`
+	Direction *string `json:"direction"`
+	Format    string  `json:"format"`
+	Indent    int     `json:"indent"`
+	Type      string  `json:"type"`
+	Version   int     `json:"version"`
+}
+
+// elementWire is the decoding counterpart of elementJSON.
+type elementWire struct {
+	Children  []json.RawMessage `json:"children"`
+	Direction string            `json:"direction"`
+	Format    interface{}       `json:"format"`
+	Indent    int               `json:"indent"`
+}
+
+func (e *Element) encode(typ string) elementJSON {
+	return elementJSON{
+		Children:  children(e.Children),
+		Direction: nullable(e.Direction),
+		Format:    e.Align,
+		Indent:    e.Indent,
+		Type:      typ,
+		Version:   1,
+	}
+}
+
+func (e *Element) decode(w *elementWire) error {
+	nodes, err := decodeNodes(w.Children)
+	if err != nil {
+		return err
+	}
+	// Older documents encode the alignment as a number; only strings are kept.
+	align, _ := w.Format.(string)
+	*e = Element{Children: nodes, Direction: w.Direction, Align: align, Indent: w.Indent}
+	return nil
+}
+
+// Paragraph is a block of inline nodes.
+type Paragraph struct {
+	Element
+}
+
+// NewParagraph returns a paragraph containing children.
+func NewParagraph(children ...Node) *Paragraph {
+	return &Paragraph{Element{Children: children, Direction: "ltr"}}
+}
+
+// Type returns "paragraph".
+func (*Paragraph) Type() string { return "paragraph" }
+
+// MarshalJSON encodes the paragraph.
+func (p *Paragraph) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.encode("paragraph"))
+}
+
+// UnmarshalJSON decodes a paragraph.
+func (p *Paragraph) UnmarshalJSON(data []byte) error {
+	var w elementWire
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	return p.decode(&w)
+}
+
+// Heading is a section heading.
+type Heading struct {
+	Element
+	// Tag is the HTML heading tag, "h1" to "h6".
+	Tag string
+}
+
+// NewHeading returns a heading of the given level, 1 to 6.
+func NewHeading(level int, children ...Node) *Heading {
+	if level < 1 {
+		level = 1
+	} else if level > 6 {
+		level = 6
+	}
+	return &Heading{Element{Children: children, Direction: "ltr"}, fmt.Sprintf("h%d", level)}
+}
+
+// Level returns the heading level from Tag, or 0 if Tag is not h1 to h6.
+func (h *Heading) Level() int {
+	var level int
+	if _, err := fmt.Sscanf(h.Tag, "h%d", &level); err != nil || level < 1 || level > 6 {
+		return 0
+	}
+	return level
+}
+
+// Type returns "heading".
+func (*Heading) Type() string { return "heading" }
+
+// MarshalJSON encodes the heading.
+func (h *Heading) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		elementJSON
+		Tag string `json:"tag"`
+	}{h.encode("heading"), h.Tag})
+}
+
+// UnmarshalJSON decodes a heading.
+func (h *Heading) UnmarshalJSON(data []byte) error {
+	var w struct {
+		elementWire
+		Tag string `json:"tag"`
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	h.Tag = w.Tag
+	return h.decode(&w.elementWire)
+}
+
+// Quote is a block quotation.
+type Quote struct {
+	Element
+}
+
+// NewQuote returns a quote containing children.
+func NewQuote(children ...Node) *Quote {
+	return &Quote{Element{Children: children, Direction: "ltr"}}
+}
+
+// Type returns "quote".
+func (*Quote) Type() string { return "quote" }
+
+// MarshalJSON encodes the quote.
+func (q *Quote) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.encode("quote"))
+}
+
+// UnmarshalJSON decodes a quote.
+func (q *Quote) UnmarshalJSON(data []byte) error {
+	var w elementWire
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	return q.decode(&w)
+}
+
+// List is a bulleted, numbered or check list. Its children are *ListItem.
+type List struct {
+	Element
+	// ListType is ListBullet, ListNumber or ListCheck.
+	ListType string
+	// Start is the number of the first item of a numbered list.
+	Start int
+}
+
+// NewList returns a list of the given type. Item values are numbered from 1.
+func NewList(listType string, items ...*ListItem) *List {
+	l := &List{Element: Element{Direction: "ltr"}, ListType: listType, Start: 1}
+	for i, item := range items {
+		item.Value = i + 1
+		l.Children = append(l.Children, item)
+	}
+	return l
+}
+
+// NewBulletList returns a bulleted list.
+func NewBulletList(items ...*ListItem) *List {
+	return NewList(ListBullet, items...)
+}
+
+// NewNumberedList returns a numbered list.
+func NewNumberedList(items ...*ListItem) *List {
+	return NewList(ListNumber, items...)
+}
+
+// Tag returns the HTML tag for the list, "ol" for numbered lists and "ul"
+// otherwise.
+func (l *List) Tag() string {
+	if l.ListType == ListNumber {
+		return "ol"
+	}
+	return "ul"
+}
+
+// Type returns "list".
+func (*List) Type() string { return "list" }
+
+// MarshalJSON encodes the list.
+func (l *List) MarshalJSON() ([]byte, error) {
+	start := l.Start
+	if start == 0 {
+		start = 1
+	}
+	return json.Marshal(struct {
+		elementJSON
+		ListType string `json:"listType"`
+		Start    int    `json:"start"`
+		Tag      string `json:"tag"`
+	}{l.encode("list"), l.ListType, start, l.Tag()})
+}
+
+// UnmarshalJSON decodes a list.
+func (l *List) UnmarshalJSON(data []byte) error {
+	var w struct {
+		elementWire
+		ListType string `json:"listType"`
+		Start    int    `json:"start"`
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	l.ListType, l.Start = w.ListType, w.Start
+	return l.decode(&w.elementWire)
+}
+
+// ListItem is an item of a List. Nested lists are children of an item.
+type ListItem struct {
+	Element
+	// Value is the item's 1-based position, set by NewList.
+	Value int
+	// Checked is the state of an item of a check list, nil otherwise.
+	Checked *bool
+}
+
+// NewListItem returns a list item containing children.
+func NewListItem(children ...Node) *ListItem {
+	return &ListItem{Element: Element{Children: children, Direction: "ltr"}}
+}
+
+// Type returns "listitem".
+func (*ListItem) Type() string { return "listitem" }
+
+// MarshalJSON encodes the list item.
+func (li *ListItem) MarshalJSON() ([]byte, error) {
+	value := li.Value
+	if value == 0 {
+		value = 1
+	}
+	return json.Marshal(struct {
+		elementJSON
+		Value   int   `json:"value"`
+		Checked *bool `json:"checked,omitempty"`
+	}{li.encode("listitem"), value, li.Checked})
+}
+
+// UnmarshalJSON decodes a list item.
+func (li *ListItem) UnmarshalJSON(data []byte) error {
+	var w struct {
+		elementWire
+		Value   int   `json:"value"`
+		Checked *bool `json:"checked"`
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	li.Value, li.Checked = w.Value, w.Checked
+	return li.decode(&w.elementWire)
+}
+
+// Link is a hyperlink around inline nodes.
+type Link struct {
+	Element
+	URL    string
+	Rel    string
+	Target string
+	Title  string
+}
+
+// NewLink returns a link to url around children.
+func NewLink(url string, children ...Node) *Link {
+	return &Link{Element: Element{Children: children, Direction: "ltr"}, URL: url}
+}
+
+// Type returns "link".
+func (*Link) Type() string { return "link" }
+
+// MarshalJSON encodes the link.
+func (l *Link) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		elementJSON
+		Rel    *string `json:"rel"`
+		Target *string `json:"target"`
+		Title  *string `json:"title"`
+		URL    string  `json:"url"`
+	}{l.encode("link"), nullable(l.Rel), nullable(l.Target), nullable(l.Title), l.URL})
+}
+
+// UnmarshalJSON decodes a link.
+func (l *Link) UnmarshalJSON(data []byte) error {
+	var w struct {
+		elementWire
+		Rel    string `json:"rel"`
+		Target string `json:"target"`
+		Title  string `json:"title"`
+		URL    string `json:"url"`
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	l.URL, l.Rel, l.Target, l.Title = w.URL, w.Rel, w.Target, w.Title
+	return l.decode(&w.elementWire)
+}
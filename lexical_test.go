@@ -0,0 +1,144 @@
+package libecto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/visionik/libecto/lexical"
+)
+
+func TestPost_SetLexical(t *testing.T) {
+	post := &Post{Title: "T", HTML: "<p>old</p>", Mobiledoc: "{}"}
+	doc := lexical.New(lexical.NewParagraph(lexical.NewText("Hello")))
+	require.NoError(t, post.SetLexical(doc))
+	assert.Empty(t, post.HTML)
+	assert.Empty(t, post.Mobiledoc)
+	assert.Contains(t, post.Lexical, `"text":"Hello"`)
+
+	got, err := post.LexicalDocument()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", got.Children[0].(*lexical.Paragraph).Children[0].(*lexical.Text).Text)
+
+	empty, err := (&Post{}).LexicalDocument()
+	require.NoError(t, err)
+	assert.Empty(t, empty.Children)
+
+	_, err = (&Post{Lexical: "{"}).LexicalDocument()
+	assert.ErrorContains(t, err, "failed to decode lexical")
+}
+
+func TestPage_SetLexical(t *testing.T) {
+	page := &Page{HTML: "<p>old</p>"}
+	require.NoError(t, page.SetLexical(lexical.New(lexical.NewHorizontalRule())))
+	assert.Empty(t, page.HTML)
+
+	got, err := page.LexicalDocument()
+	require.NoError(t, err)
+	assert.IsType(t, &lexical.HorizontalRule{}, got.Children[0])
+}
+
+func TestWritePath(t *testing.T) {
+	assert.Equal(t, "/posts/?formats=html&source=html", writePath("/posts/", "<p>x</p>", "", ""))
+	assert.Equal(t, "/posts/?formats=html", writePath("/posts/", "<p>x</p>", `{"root":{}}`, ""))
+	assert.Equal(t, "/posts/?formats=html", writePath("/posts/", "<p>x</p>", "", "{}"))
+	assert.Equal(t, "/pages/1/?formats=html", writePath("/pages/1/", "", "", ""))
+}
+
+func TestClient_WriteLexical(t *testing.T) {
+	var sources []string
+	var bodies []map[string][]map[string]interface{}
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		sources = append(sources, r.URL.Query().Get("source"))
+		var body map[string][]map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		if r.URL.Path == "/ghost/api/admin/pages/" || r.URL.Path == "/ghost/api/admin/pages/p1/" {
+			json.NewEncoder(w).Encode(PagesResponse{Pages: []Page{{ID: "p1"}}})
+			return
+		}
+		json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{{ID: "1"}}})
+	})
+	defer server.Close()
+	ctx := context.Background()
+
+	post := &Post{Title: "Lexical"}
+	require.NoError(t, post.SetLexical(lexical.New(lexical.NewCodeBlock("x := 1", "go"))))
+	_, err := client.CreatePostContext(ctx, post)
+	require.NoError(t, err)
+	_, err = client.UpdatePostContext(ctx, "1", post)
+	require.NoError(t, err)
+	_, err = client.CreatePostContext(ctx, &Post{Title: "HTML", HTML: "<p>hi</p>"})
+	require.NoError(t, err)
+
+	page := &Page{Title: "Page"}
+	require.NoError(t, page.SetLexical(lexical.New()))
+	_, err = client.CreatePageContext(ctx, page)
+	require.NoError(t, err)
+	_, err = client.UpdatePageContext(ctx, "p1", &Page{HTML: "<p>hi</p>"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", "", "html", "", "html"}, sources)
+	sent, err := lexical.ParseString(bodies[0]["posts"][0]["lexical"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "go", sent.Children[0].(*lexical.CodeBlock).Language)
+	assert.NotContains(t, bodies[0]["posts"][0], "html")
+}
+
+func TestClient_EditFetchedLexical(t *testing.T) {
+	stored := lexical.New(lexical.NewParagraph(lexical.NewText("First")), lexical.NewHorizontalRule())
+	data, err := json.Marshal(stored)
+	require.NoError(t, err)
+
+	var queries []url.Values
+	var sent []string
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		if r.Method == "PUT" {
+			var body map[string][]map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			for _, items := range body {
+				sent = append(sent, items[0]["lexical"].(string))
+			}
+		}
+		// Like Ghost, return Lexical only when it is asked for.
+		item := map[string]string{"id": "1", "html": "<p>First</p><hr>"}
+		if strings.Contains(r.URL.Query().Get("formats"), FormatLexical) {
+			item["lexical"] = string(data)
+		}
+		json.NewEncoder(w).Encode(map[string][]map[string]string{"posts": {item}, "pages": {item}})
+	})
+	defer server.Close()
+
+	post, err := client.GetPost("1")
+	require.NoError(t, err)
+	doc, err := post.LexicalDocument()
+	require.NoError(t, err)
+	require.Len(t, doc.Children, 2, "GetPost should return the Lexical content")
+	doc.Append(lexical.NewParagraph(lexical.NewText("Second")))
+	require.NoError(t, post.SetLexical(doc))
+	_, err = client.UpdatePost(post.ID, post)
+	require.NoError(t, err)
+
+	page, err := client.GetPage("1")
+	require.NoError(t, err)
+	page.Title = "Renamed"
+	_, err = client.UpdatePage(page.ID, page)
+	require.NoError(t, err)
+
+	require.Len(t, queries, 4)
+	assert.Empty(t, queries[1].Get("source"))
+	assert.Empty(t, queries[3].Get("source"))
+	require.Len(t, sent, 2)
+	edited, err := lexical.ParseString(sent[0])
+	require.NoError(t, err)
+	require.Len(t, edited.Children, 3)
+	assert.IsType(t, &lexical.HorizontalRule{}, edited.Children[1])
+	assert.Equal(t, string(data), sent[1], "unedited Lexical is sent back unchanged")
+}
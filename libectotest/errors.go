@@ -0,0 +1,90 @@
+package libectotest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/visionik/libecto"
+)
+
+// apiError is an error response in Ghost's format.
+type apiError struct {
+	status  int
+	Message string
+	Context string
+	Type    string
+}
+
+func writeError(w http.ResponseWriter, e *apiError) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(e.status)
+	json.NewEncoder(w).Encode(libecto.ErrorResponse{Errors: []libecto.APIError{{
+		Message: e.Message,
+		Context: e.Context,
+		Type:    e.Type,
+	}}})
+}
+
+func errNotFound(msg string) *apiError {
+	return &apiError{status: http.StatusNotFound, Message: msg, Type: libecto.ErrorTypeNotFound}
+}
+
+func errValidation(msg string) *apiError {
+	return &apiError{status: http.StatusUnprocessableEntity, Message: msg, Type: libecto.ErrorTypeValidation}
+}
+
+// errInvalidID is the error Ghost returns when an ID path segment is not
+// an ObjectId.
+func errInvalidID(c *collection, method string) *apiError {
+	action := "read"
+	switch method {
+	case http.MethodPut:
+		action = "edit"
+	case http.MethodDelete:
+		action = "destroy"
+	}
+	return &apiError{
+		status:  http.StatusUnprocessableEntity,
+		Message: "Validation error, cannot " + action + " " + strings.TrimSuffix(c.name, "s") + ".",
+		Context: "Validation (matches) failed for id",
+		Type:    libecto.ErrorTypeValidation,
+	}
+}
+
+func errUnauthorized(msg string) *apiError {
+	return &apiError{status: http.StatusUnauthorized, Message: msg, Type: libecto.ErrorTypeUnauthorized}
+}
+
+func errCollision() *apiError {
+	return &apiError{
+		status:  http.StatusConflict,
+		Message: "Saving failed! Someone else is editing this post.",
+		Type:    libecto.ErrorTypeUpdateCollision,
+	}
+}
+
+func errBadRequest(msg, context string) *apiError {
+	return &apiError{status: http.StatusBadRequest, Message: msg, Context: context, Type: "BadRequestError"}
+}
+
+func errMethod() *apiError {
+	return &apiError{status: http.StatusMethodNotAllowed, Message: "Method not allowed", Type: "MethodNotAllowedError"}
+}
+
+func notFoundMessage(c *collection) string {
+	switch c.name {
+	case "posts":
+		return "Post not found."
+	case "pages":
+		return "Page not found."
+	case "tags":
+		return "Tag not found."
+	case "users":
+		return "User not found."
+	case "newsletters":
+		return "Newsletter not found."
+	default:
+		return "Webhook not found."
+	}
+}
@@ -0,0 +1,201 @@
+package libectotest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// filter is a parsed NQL expression in disjunctive normal form:
+// the item matches if every term of any group matches.
+type filter struct {
+	groups [][]term
+}
+
+// term is a single "field:value" comparison.
+type term struct {
+	field  string
+	values []string
+	negate bool
+}
+
+// fieldAliases maps NQL shorthands to the rendered fields they compare.
+var fieldAliases = map[string]string{
+	"tag":            "tags.slug",
+	"tags":           "tags.slug",
+	"author":         "authors.slug",
+	"authors":        "authors.slug",
+	"primary_tag":    "primary_tag.slug",
+	"primary_author": "primary_author.slug",
+}
+
+// parseFilter parses the subset of NQL supported by the fake: terms of the
+// form field:value, field:'quoted value', field:[a,b] and field:-value,
+// combined with + (and) and , (or). Grouping with parentheses and the
+// comparison operators >, <, ~ are rejected with a 400 like malformed filters.
+func parseFilter(expr string) (*filter, *apiError) {
+	f := &filter{}
+	if strings.TrimSpace(expr) == "" {
+		return f, nil
+	}
+
+	for _, group := range splitTopLevel(expr, ',') {
+		var terms []term
+		for _, raw := range splitTopLevel(group, '+') {
+			t, err := parseTerm(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, errBadRequest("Error parsing filter", err.Error())
+			}
+			terms = append(terms, t)
+		}
+		f.groups = append(f.groups, terms)
+	}
+	return f, nil
+}
+
+func parseTerm(raw string) (term, error) {
+	field, value, ok := strings.Cut(raw, ":")
+	if !ok || field == "" || value == "" {
+		return term{}, fmt.Errorf("invalid filter term %q", raw)
+	}
+	if strings.ContainsAny(field, "()") {
+		return term{}, fmt.Errorf("grouping is not supported in %q", raw)
+	}
+	if alias, ok := fieldAliases[field]; ok {
+		field = alias
+	}
+
+	t := term{field: field}
+	if strings.HasPrefix(value, "-") {
+		t.negate = true
+		value = value[1:]
+	}
+	if len(value) > 0 && strings.ContainsRune(">~<", rune(value[0])) {
+		return term{}, fmt.Errorf("operator %q is not supported", value[:1])
+	}
+
+	if strings.HasPrefix(value, "[") {
+		if !strings.HasSuffix(value, "]") {
+			return term{}, fmt.Errorf("unterminated list in %q", raw)
+		}
+		for _, v := range splitTopLevel(value[1:len(value)-1], ',') {
+			s, err := unquote(strings.TrimSpace(v))
+			if err != nil {
+				return term{}, err
+			}
+			t.values = append(t.values, s)
+		}
+		return t, nil
+	}
+
+	s, err := unquote(value)
+	if err != nil {
+		return term{}, err
+	}
+	t.values = []string{s}
+	return t, nil
+}
+
+// unquote strips single quotes and resolves backslash escapes.
+func unquote(v string) (string, error) {
+	if !strings.HasPrefix(v, "'") {
+		if strings.ContainsAny(v, "()") {
+			return "", fmt.Errorf("grouping is not supported in %q", v)
+		}
+		return v, nil
+	}
+	if len(v) < 2 || !strings.HasSuffix(v, "'") {
+		return "", fmt.Errorf("unterminated string %s", v)
+	}
+	var b strings.Builder
+	inner := v[1 : len(v)-1]
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' && i+1 < len(inner) {
+			i++
+		}
+		b.WriteByte(inner[i])
+	}
+	return b.String(), nil
+}
+
+// splitTopLevel splits s on sep, ignoring separators inside quotes or brackets.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && quoted:
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// match reports whether the rendered item satisfies the filter.
+func (f *filter) match(it item) bool {
+	if len(f.groups) == 0 {
+		return true
+	}
+	for _, group := range f.groups {
+		all := true
+		for _, t := range group {
+			if !t.match(it) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+func (t term) match(it item) bool {
+	found := false
+	for _, have := range fieldValues(it, strings.Split(t.field, ".")) {
+		for _, want := range t.values {
+			if have == want {
+				found = true
+			}
+		}
+	}
+	return found != t.negate
+}
+
+// fieldValues collects the string forms of the value at path, descending into lists.
+func fieldValues(v interface{}, path []string) []string {
+	switch val := v.(type) {
+	case []interface{}:
+		var out []string
+		for _, elem := range val {
+			out = append(out, fieldValues(elem, path)...)
+		}
+		return out
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return nil
+		}
+		return fieldValues(val[path[0]], path[1:])
+	case nil:
+		if len(path) == 0 {
+			return []string{"null"}
+		}
+		return nil
+	default:
+		if len(path) != 0 {
+			return nil
+		}
+		return []string{fmt.Sprint(val)}
+	}
+}
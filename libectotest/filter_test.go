@@ -0,0 +1,81 @@
+package libectotest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visionik/libecto/nql"
+)
+
+func TestParseFilter(t *testing.T) {
+	post := item{
+		"status":   "published",
+		"featured": true,
+		"title":    "It's here",
+		"tags":     []interface{}{item{"slug": "news"}, item{"slug": "go"}},
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"", true},
+		{"status:published", true},
+		{"status:draft", false},
+		{"status:'published'", true},
+		{"status:[draft,published]", true},
+		{"status:-draft", true},
+		{"status:-[draft,published]", false},
+		{"featured:true", true},
+		{"status:draft,featured:true", true},
+		{"status:published+featured:false", false},
+		{"tag:go", true},
+		{"tags:[rust,news]", true},
+		{"tag:-news", false},
+		{`title:'It\'s here'`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := parseFilter(tt.filter)
+			require.Nil(t, err)
+			assert.Equal(t, tt.want, f.match(post))
+		})
+	}
+}
+
+func TestParseFilter_Builder(t *testing.T) {
+	post := item{"status": "published", "title": `It's "here" \o/`, "tags": []interface{}{item{"slug": "hash tag"}}}
+
+	tests := []struct {
+		expr nql.Expr
+		want bool
+	}{
+		{nql.Eq("title", `It's "here" \o/`), true},
+		{nql.And(nql.Eq("status", "published"), nql.In("tag", "news", "hash tag")), true},
+		{nql.Or(nql.Eq("status", "draft"), nql.NotIn("tag", "hash tag")), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr.String(), func(t *testing.T) {
+			f, err := parseFilter(tt.expr.String())
+			require.Nil(t, err)
+			assert.Equal(t, tt.want, f.match(post))
+		})
+	}
+}
+
+func TestParseFilter_Unsupported(t *testing.T) {
+	for _, expr := range []string{"status", "(status:draft)", "published_at:>2024", "status:[draft", "title:'open"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := parseFilter(expr)
+			require.NotNil(t, err)
+			assert.Equal(t, 400, err.status)
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "hello-world", slugify("Hello, World!"))
+	assert.Equal(t, "café-au-lait", slugify("  café au lait "))
+	assert.Equal(t, "", slugify("!!!"))
+}
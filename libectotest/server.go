@@ -0,0 +1,526 @@
+// Package libectotest provides an in-memory fake of the Ghost Admin API for tests.
+//
+// The fake runs on net/http/httptest and keeps posts, pages, tags, users,
+// newsletters, webhooks and uploaded images in memory. It behaves like Ghost
+// where clients are likely to notice: IDs are 24-character hex strings and
+// any other value in an ID path fails with a 422 ValidationError, slugs
+// are generated from titles and kept unique, updates must carry the current
+// updated_at or fail with a 409 UpdateCollisionError, list endpoints support
+// NQL filters and pagination, and every request must carry a valid Admin API
+// JWT as produced by libecto.GenerateToken.
+//
+//	srv := libectotest.NewServer()
+//	defer srv.Close()
+//	client := srv.Client()
+//	post, _ := client.CreatePost(&libecto.Post{Title: "Hello"})
+//	client.PublishPost(post.ID)
+package libectotest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/visionik/libecto"
+)
+
+// apiPrefix is the path prefix of the Admin API.
+const apiPrefix = "/ghost/api/admin"
+
+// timeFormat is the timestamp format used by Ghost.
+const timeFormat = "2006-01-02T15:04:05.000Z"
+
+// maxTokenLifetime is the longest token lifetime Ghost accepts.
+const maxTokenLifetime = 5 * time.Minute
+
+// Upload describes an image received by the fake server.
+type Upload struct {
+	// Filename is the name from the multipart Content-Disposition header.
+	Filename string
+	// ContentType is the declared content type of the file part.
+	ContentType string
+	// Size is the number of bytes received.
+	Size int
+	// URL is the URL returned to the client.
+	URL string
+}
+
+// Server is a stateful fake Ghost Admin API server.
+// It is safe for concurrent use.
+type Server struct {
+	// URL is the site URL to pass to libecto.NewClient.
+	URL string
+	// APIKey is the Admin API key accepted by the server.
+	APIKey string
+
+	server *httptest.Server
+	keyID  string
+	secret []byte
+	now    func() time.Time
+
+	mu          sync.Mutex
+	lastTime    time.Time
+	seq         int
+	site        libecto.Site
+	settings    []libecto.Setting
+	posts       *collection
+	pages       *collection
+	tags        *collection
+	users       *collection
+	newsletters *collection
+	webhooks    *collection
+	uploads     []Upload
+	images      map[string][]byte
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAPIKey sets the Admin API key accepted by the server.
+// By default a random key is generated.
+func WithAPIKey(apiKey string) Option {
+	return func(s *Server) {
+		s.APIKey = apiKey
+	}
+}
+
+// WithClock sets the clock used for created_at, updated_at and published_at.
+// Token validation always uses the real time.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithSite sets the response of the /site/ endpoint.
+func WithSite(site libecto.Site) Option {
+	return func(s *Server) {
+		s.site = site
+	}
+}
+
+// NewServer starts a fake Ghost Admin API server seeded with an owner user
+// and a default newsletter. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		now:         time.Now,
+		site:        libecto.Site{Title: "Ghost Test", Description: "A fake Ghost site", Version: "5.82"},
+		settings:    []libecto.Setting{{Key: "title", Value: "Ghost Test"}, {Key: "description", Value: "A fake Ghost site"}, {Key: "timezone", Value: "Etc/UTC"}},
+		posts:       newCollection("posts"),
+		pages:       newCollection("pages"),
+		tags:        newCollection("tags"),
+		users:       newCollection("users"),
+		newsletters: newCollection("newsletters"),
+		webhooks:    newCollection("webhooks"),
+		images:      map[string][]byte{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.APIKey == "" {
+		s.APIKey = randomHex(12) + ":" + randomHex(32)
+	}
+	id, secret, err := libecto.ParseAPIKey(s.APIKey)
+	if err != nil {
+		panic("libectotest: " + err.Error())
+	}
+	s.keyID, s.secret = id, secret
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	if s.site.URL == "" {
+		s.site.URL = s.URL
+	}
+
+	s.AddUser(libecto.Author{Name: "Ghost Owner", Email: "owner@example.com"})
+	s.AddNewsletter(libecto.Newsletter{Name: "Default Newsletter", Status: "active", SubscribeOnSignup: true})
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a libecto.Client configured for the server.
+func (s *Server) Client(opts ...libecto.ClientOption) *libecto.Client {
+	return libecto.NewClient(s.URL, s.APIKey, opts...)
+}
+
+// AddPost stores a post as if it had been created through the API and returns it.
+func (s *Server) AddPost(post libecto.Post) libecto.Post {
+	var out libecto.Post
+	s.seed(s.posts, post, &out)
+	return out
+}
+
+// AddPage stores a page as if it had been created through the API and returns it.
+func (s *Server) AddPage(page libecto.Page) libecto.Page {
+	var out libecto.Page
+	s.seed(s.pages, page, &out)
+	return out
+}
+
+// AddTag stores a tag as if it had been created through the API and returns it.
+func (s *Server) AddTag(tag libecto.Tag) libecto.Tag {
+	var out libecto.Tag
+	s.seed(s.tags, tag, &out)
+	return out
+}
+
+// AddUser stores a staff user and returns it.
+func (s *Server) AddUser(user libecto.Author) libecto.Author {
+	var out libecto.Author
+	s.seed(s.users, user, &out)
+	return out
+}
+
+// AddNewsletter stores a newsletter and returns it.
+func (s *Server) AddNewsletter(newsletter libecto.Newsletter) libecto.Newsletter {
+	var out libecto.Newsletter
+	s.seed(s.newsletters, newsletter, &out)
+	return out
+}
+
+// AddWebhook stores a webhook and returns it.
+func (s *Server) AddWebhook(webhook libecto.Webhook) libecto.Webhook {
+	var out libecto.Webhook
+	s.seed(s.webhooks, webhook, &out)
+	return out
+}
+
+// Posts returns a snapshot of the stored posts, newest first.
+func (s *Server) Posts() []libecto.Post {
+	var out []libecto.Post
+	s.snapshot(s.posts, &out)
+	return out
+}
+
+// Pages returns a snapshot of the stored pages, newest first.
+func (s *Server) Pages() []libecto.Page {
+	var out []libecto.Page
+	s.snapshot(s.pages, &out)
+	return out
+}
+
+// Tags returns a snapshot of the stored tags.
+func (s *Server) Tags() []libecto.Tag {
+	var out []libecto.Tag
+	s.snapshot(s.tags, &out)
+	return out
+}
+
+// Webhooks returns a snapshot of the stored webhooks.
+func (s *Server) Webhooks() []libecto.Webhook {
+	var out []libecto.Webhook
+	s.snapshot(s.webhooks, &out)
+	return out
+}
+
+// Uploads returns the images uploaded so far, in order.
+func (s *Server) Uploads() []Upload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Upload(nil), s.uploads...)
+}
+
+// seed creates an item from a libecto value and decodes the result into out.
+func (s *Server) seed(c *collection, in, out interface{}) {
+	item := toItem(in)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	created, apiErr := s.create(c, item)
+	if apiErr != nil {
+		panic("libectotest: " + apiErr.Message)
+	}
+	fromItem(s.render(c, created), out)
+}
+
+// snapshot decodes every rendered item of c into out, which must point to a slice.
+func (s *Server) snapshot(c *collection, out interface{}) {
+	s.mu.Lock()
+	items := make([]item, 0, len(c.items))
+	for _, it := range c.ordered() {
+		items = append(items, s.render(c, it))
+	}
+	s.mu.Unlock()
+	fromItem(items, out)
+}
+
+// serveHTTP authenticates and dispatches a request.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/content/images/") {
+		s.serveImage(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, apiPrefix+"/") {
+		writeError(w, errNotFound("Resource not found"))
+		return
+	}
+	if err := s.authenticate(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	segments := strings.FieldsFunc(strings.TrimPrefix(r.URL.Path, apiPrefix), func(c rune) bool { return c == '/' })
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status, body, apiErr := s.route(r, segments)
+	if apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+	writeJSON(w, status, body)
+}
+
+// route dispatches an authenticated request to the matching endpoint.
+func (s *Server) route(r *http.Request, segments []string) (int, interface{}, *apiError) {
+	if len(segments) == 0 {
+		return 0, nil, errNotFound("Resource not found")
+	}
+	switch resource := segments[0]; resource {
+	case "site":
+		if r.Method != http.MethodGet {
+			return 0, nil, errMethod()
+		}
+		return http.StatusOK, map[string]interface{}{"site": s.site}, nil
+	case "settings":
+		if r.Method != http.MethodGet {
+			return 0, nil, errMethod()
+		}
+		return http.StatusOK, map[string]interface{}{"settings": s.settings}, nil
+	case "images":
+		if r.Method != http.MethodPost || len(segments) != 2 || segments[1] != "upload" {
+			return 0, nil, errNotFound("Resource not found")
+		}
+		return s.uploadImage(r)
+	case "posts", "pages", "tags", "users", "newsletters", "webhooks":
+		return s.routeCollection(r, s.collection(resource), segments[1:])
+	default:
+		return 0, nil, errNotFound("Resource not found")
+	}
+}
+
+func (s *Server) collection(name string) *collection {
+	switch name {
+	case "posts":
+		return s.posts
+	case "pages":
+		return s.pages
+	case "tags":
+		return s.tags
+	case "users":
+		return s.users
+	case "newsletters":
+		return s.newsletters
+	default:
+		return s.webhooks
+	}
+}
+
+// routeCollection handles the standard browse/read/add/edit/destroy endpoints.
+func (s *Server) routeCollection(r *http.Request, c *collection, rest []string) (int, interface{}, *apiError) {
+	readOnly := c == s.users || c == s.newsletters
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		if c == s.webhooks {
+			return http.StatusOK, map[string]interface{}{c.name: s.renderAll(c, c.ordered())}, nil
+		}
+		return s.browse(r, c)
+
+	case len(rest) == 0 && r.Method == http.MethodPost && !readOnly:
+		in, apiErr := decodeSingle(r, c.name)
+		if apiErr != nil {
+			return 0, nil, apiErr
+		}
+		created, apiErr := s.create(c, in)
+		if apiErr != nil {
+			return 0, nil, apiErr
+		}
+		return http.StatusCreated, map[string]interface{}{c.name: []item{s.render(c, created)}}, nil
+
+	case len(rest) == 2 && rest[0] == "slug" && r.Method == http.MethodGet && c != s.webhooks:
+		it := c.find("slug", rest[1])
+		if it == nil {
+			return 0, nil, errNotFound(notFoundMessage(c))
+		}
+		return http.StatusOK, map[string]interface{}{c.name: []item{s.render(c, it)}}, nil
+
+	case len(rest) == 1:
+		if !libecto.IsObjectID(rest[0]) {
+			return 0, nil, errInvalidID(c, r.Method)
+		}
+		it := c.find("id", rest[0])
+		if it == nil {
+			return 0, nil, errNotFound(notFoundMessage(c))
+		}
+		switch {
+		case r.Method == http.MethodGet && c != s.webhooks:
+			return http.StatusOK, map[string]interface{}{c.name: []item{s.render(c, it)}}, nil
+		case r.Method == http.MethodPut && !readOnly:
+			in, apiErr := decodeSingle(r, c.name)
+			if apiErr != nil {
+				return 0, nil, apiErr
+			}
+			updated, apiErr := s.update(c, it, in)
+			if apiErr != nil {
+				return 0, nil, apiErr
+			}
+			return http.StatusOK, map[string]interface{}{c.name: []item{s.render(c, updated)}}, nil
+		case r.Method == http.MethodDelete && !readOnly:
+			s.destroy(c, it)
+			return http.StatusNoContent, nil, nil
+		}
+	}
+	return 0, nil, errMethod()
+}
+
+// authenticate validates the Admin API JWT in the Authorization header.
+func (s *Server) authenticate(r *http.Request) *apiError {
+	header := r.Header.Get("Authorization")
+	raw, ok := strings.CutPrefix(header, "Ghost ")
+	if !ok || raw == "" {
+		return errUnauthorized("Authorization header format is \"Authorization: Ghost [token]\"")
+	}
+
+	token, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		if kid, _ := t.Header["kid"].(string); kid != s.keyID {
+			return nil, fmt.Errorf("unknown Admin API Key")
+		}
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{"HS256"}),
+		jwt.WithAudience("/admin/"),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return errUnauthorized("Invalid token: " + err.Error())
+	}
+
+	claims, _ := token.Claims.(jwt.MapClaims)
+	iat, errIat := claims.GetIssuedAt()
+	exp, errExp := claims.GetExpirationTime()
+	if errIat != nil || errExp != nil || iat == nil || exp == nil {
+		return errUnauthorized("Invalid token: missing iat or exp")
+	}
+	if exp.Sub(iat.Time) > maxTokenLifetime {
+		return errUnauthorized("Invalid token: maximum token lifetime is 5 minutes")
+	}
+	return nil
+}
+
+// uploadImage stores a multipart image upload.
+func (s *Server) uploadImage(r *http.Request) (int, interface{}, *apiError) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return 0, nil, errValidation("Please select an image.")
+	}
+	defer file.Close()
+
+	switch strings.ToLower(path.Ext(header.Filename)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp", ".svg", ".svgz", ".ico":
+	default:
+		return 0, nil, &apiError{
+			status:  http.StatusUnsupportedMediaType,
+			Message: "Please select a valid image.",
+			Type:    "UnsupportedMediaTypeError",
+		}
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return 0, nil, errValidation("Could not read the uploaded file.")
+	}
+
+	now := s.now().UTC()
+	name := path.Base(header.Filename)
+	imagePath := fmt.Sprintf("/content/images/%04d/%02d/%s", now.Year(), int(now.Month()), name)
+	for i := 1; s.images[imagePath] != nil; i++ {
+		ext := path.Ext(name)
+		imagePath = fmt.Sprintf("/content/images/%04d/%02d/%s-%d%s", now.Year(), int(now.Month()), strings.TrimSuffix(name, ext), i, ext)
+	}
+	s.images[imagePath] = data
+
+	url := s.URL + imagePath
+	s.uploads = append(s.uploads, Upload{
+		Filename:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Size:        len(data),
+		URL:         url,
+	})
+	return http.StatusCreated, map[string]interface{}{
+		"images": []map[string]interface{}{{"url": url, "ref": nil}},
+	}, nil
+}
+
+// serveImage serves a previously uploaded image.
+func (s *Server) serveImage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	data, ok := s.images[r.URL.Path]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.Write(data)
+}
+
+// timestamp returns the current time in Ghost's format. Successive calls
+// always return distinct values so updated_at changes on every write.
+func (s *Server) timestamp() string {
+	now := s.now().UTC().Truncate(time.Millisecond)
+	if !now.After(s.lastTime) {
+		now = s.lastTime.Add(time.Millisecond)
+	}
+	s.lastTime = now
+	return now.Format(timeFormat)
+}
+
+// newID returns a 24-character hex ID in the style of a MongoDB ObjectId.
+func (s *Server) newID() string {
+	s.seq++
+	return fmt.Sprintf("%08x%016x", uint32(s.now().Unix()), s.seq)
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,368 @@
+package libectotest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visionik/libecto"
+)
+
+func newServer(t *testing.T, opts ...Option) (*Server, *libecto.Client) {
+	srv := NewServer(opts...)
+	t.Cleanup(srv.Close)
+	return srv, srv.Client(libecto.WithRetryPolicy(libecto.RetryPolicy{MaxAttempts: 1}))
+}
+
+func TestServer_PublishWorkflow(t *testing.T) {
+	srv, client := newServer(t)
+
+	post, err := client.CreatePost(&libecto.Post{
+		Title: "Hello World",
+		HTML:  "<p>Hi</p>",
+		Tags:  []libecto.Tag{{Name: "News"}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, post.ID, 24)
+	assert.NotEmpty(t, post.UUID)
+	assert.Equal(t, "hello-world", post.Slug)
+	assert.Equal(t, "draft", post.Status)
+	assert.Empty(t, post.PublishedAt)
+	require.Len(t, post.Tags, 1)
+	assert.Equal(t, "news", post.Tags[0].Slug)
+	require.Len(t, post.Authors, 1)
+	assert.Equal(t, "Ghost Owner", post.Authors[0].Name)
+
+	published, err := client.PublishPost("hello-world")
+	require.NoError(t, err)
+	assert.Equal(t, "published", published.Status)
+	assert.NotEmpty(t, published.PublishedAt)
+	assert.NotEqual(t, post.UpdatedAt, published.UpdatedAt)
+
+	unpublished, err := client.UnpublishPost(post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "draft", unpublished.Status)
+
+	scheduled, err := client.SchedulePost(post.ID, "2030-01-01T00:00:00.000Z")
+	require.NoError(t, err)
+	assert.Equal(t, "scheduled", scheduled.Status)
+	assert.Equal(t, "2030-01-01T00:00:00.000Z", scheduled.PublishedAt)
+
+	require.NoError(t, client.DeletePost(post.ID))
+	_, err = client.GetPost(post.ID)
+	assert.True(t, libecto.IsNotFound(err))
+	assert.Empty(t, srv.Posts())
+}
+
+func TestServer_InvalidID(t *testing.T) {
+	_, client := newServer(t)
+
+	post, err := client.CreatePost(&libecto.Post{Title: "Hello World"})
+	require.NoError(t, err)
+	assert.True(t, libecto.IsObjectID(post.ID))
+
+	// Ghost rejects non-ObjectIds on the ID endpoint with a 422, not a 404.
+	_, err = client.GetPostByID("hello-world")
+	assert.True(t, libecto.IsValidation(err), err)
+	assert.ErrorContains(t, err, "Validation (matches) failed for id")
+	assert.True(t, libecto.IsValidation(client.DeleteTag("tech")))
+	_, err = client.GetPostByID("000000000000000000000000")
+	assert.True(t, libecto.IsNotFound(err))
+
+	// GetPost still resolves slugs.
+	got, err := client.GetPost("hello-world")
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, got.ID)
+}
+
+func TestServer_UpdateCollision(t *testing.T) {
+	_, client := newServer(t)
+
+	post, err := client.CreatePost(&libecto.Post{Title: "Draft"})
+	require.NoError(t, err)
+
+	_, err = client.UpdatePost(post.ID, &libecto.Post{Title: "First", UpdatedAt: post.UpdatedAt})
+	require.NoError(t, err)
+
+	_, err = client.UpdatePost(post.ID, &libecto.Post{Title: "Stale", UpdatedAt: post.UpdatedAt})
+	require.Error(t, err)
+	assert.True(t, libecto.IsConflict(err))
+
+	_, err = client.UpdatePost(post.ID, &libecto.Post{Title: "Missing updated_at"})
+	assert.True(t, libecto.IsValidation(err))
+
+	current, err := client.GetPost(post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First", current.Title)
+}
+
+func TestServer_RoundTripFullPost(t *testing.T) {
+	_, client := newServer(t)
+
+	hide := false
+	created, err := client.CreatePost(&libecto.Post{
+		Title:                    "Launch",
+		Tags:                     []libecto.Tag{{Name: "News"}},
+		FeatureImageAlt:          "A rocket",
+		ShowTitleAndFeatureImage: &hide,
+		CanonicalURL:             "https://other.example/launch",
+		OGTitle:                  "OG",
+		TwitterDescription:       "TW desc",
+		CodeinjectionHead:        "<style></style>",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created.PrimaryTag)
+	assert.Equal(t, "news", created.PrimaryTag.Slug)
+
+	// Send back the post as returned, read-only fields included.
+	post, err := client.GetPost(created.ID)
+	require.NoError(t, err)
+	post.MetaTitle = "Meta"
+	updated, err := client.UpdatePost(post.ID, post)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Meta", updated.MetaTitle)
+	assert.Equal(t, "A rocket", updated.FeatureImageAlt)
+	require.NotNil(t, updated.ShowTitleAndFeatureImage)
+	assert.False(t, *updated.ShowTitleAndFeatureImage)
+	assert.Equal(t, "https://other.example/launch", updated.CanonicalURL)
+	assert.Equal(t, "OG", updated.OGTitle)
+	assert.Equal(t, "TW desc", updated.TwitterDescription)
+	assert.Equal(t, "<style></style>", updated.CodeinjectionHead)
+	assert.Equal(t, post.URL, updated.URL)
+	assert.Equal(t, "news", updated.PrimaryTag.Slug)
+}
+
+func TestServer_UniqueSlugs(t *testing.T) {
+	srv, client := newServer(t)
+
+	a, err := client.CreatePost(&libecto.Post{Title: "Same Title"})
+	require.NoError(t, err)
+	b, err := client.CreatePost(&libecto.Post{Title: "Same Title"})
+	require.NoError(t, err)
+	c, err := client.CreatePost(&libecto.Post{Title: "Custom", Slug: "same-title"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "same-title", a.Slug)
+	assert.Equal(t, "same-title-2", b.Slug)
+	assert.Equal(t, "same-title-3", c.Slug)
+	assert.Len(t, srv.Posts(), 3)
+	assert.Equal(t, c.ID, srv.Posts()[0].ID, "newest first")
+}
+
+func TestServer_StatusFilterAndPagination(t *testing.T) {
+	srv, client := newServer(t)
+	for i := 0; i < 5; i++ {
+		srv.AddPost(libecto.Post{Title: "Draft", Status: "draft"})
+	}
+	for i := 0; i < 3; i++ {
+		srv.AddPost(libecto.Post{Title: "Live", Status: "published"})
+	}
+
+	resp, err := client.ListPosts("published", 0)
+	require.NoError(t, err)
+	assert.Len(t, resp.Posts, 3)
+	for _, p := range resp.Posts {
+		assert.Equal(t, "published", p.Status)
+	}
+
+	resp, err = client.ListPosts("draft", 2)
+	require.NoError(t, err)
+	assert.Len(t, resp.Posts, 2)
+	require.NotNil(t, resp.Meta)
+	assert.Equal(t, 5, resp.Meta.Pagination.Total)
+	assert.Equal(t, 3, resp.Meta.Pagination.Pages)
+	require.NotNil(t, resp.Meta.Pagination.Next)
+	assert.Equal(t, 2, *resp.Meta.Pagination.Next)
+	assert.Nil(t, resp.Meta.Pagination.Prev)
+
+	resp, err = client.ListPosts("all", 0)
+	require.NoError(t, err)
+	assert.Len(t, resp.Posts, 8)
+
+	resp, err = client.ListPostsWithOptions(context.Background(), &libecto.ListOptions{Filter: "status:draft", Limit: libecto.LimitAll})
+	require.NoError(t, err)
+	assert.Len(t, resp.Posts, 5)
+	assert.Equal(t, libecto.LimitAll, resp.Meta.Pagination.Limit)
+	assert.Nil(t, resp.Meta.Pagination.Next)
+}
+
+func TestServer_Pages(t *testing.T) {
+	_, client := newServer(t)
+
+	page, err := client.CreatePage(&libecto.Page{Title: "About"})
+	require.NoError(t, err)
+	assert.Equal(t, "about", page.Slug)
+
+	published, err := client.PublishPage("about")
+	require.NoError(t, err)
+	assert.Equal(t, "published", published.Status)
+
+	resp, err := client.ListPages("published", 0)
+	require.NoError(t, err)
+	assert.Len(t, resp.Pages, 1)
+
+	require.NoError(t, client.DeletePage(page.ID))
+	resp, err = client.ListPages("", 0)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Pages)
+}
+
+func TestServer_Tags(t *testing.T) {
+	srv, client := newServer(t)
+
+	tag, err := client.CreateTag(&libecto.Tag{Name: "Getting Started"})
+	require.NoError(t, err)
+	assert.Equal(t, "getting-started", tag.Slug)
+	assert.Equal(t, "public", tag.Visibility)
+
+	internal, err := client.CreateTag(&libecto.Tag{Name: "#hidden"})
+	require.NoError(t, err)
+	assert.Equal(t, "internal", internal.Visibility)
+	assert.Equal(t, "hidden", internal.Slug)
+
+	_, err = client.CreateTag(&libecto.Tag{})
+	assert.True(t, libecto.IsValidation(err))
+
+	srv.AddPost(libecto.Post{Title: "Tagged", Tags: []libecto.Tag{{Slug: "getting-started"}}})
+
+	updated, err := client.UpdateTag(tag.ID, &libecto.Tag{Description: "Start here"})
+	require.NoError(t, err)
+	assert.Equal(t, "Start here", updated.Description)
+	assert.Equal(t, "Getting Started", updated.Name)
+
+	got, err := client.GetTag("getting-started")
+	require.NoError(t, err)
+	assert.Equal(t, tag.ID, got.ID)
+
+	list, err := client.ListTags(0)
+	require.NoError(t, err)
+	assert.Len(t, list.Tags, 2)
+
+	require.NoError(t, client.DeleteTag(tag.ID))
+	assert.Empty(t, srv.Posts()[0].Tags)
+}
+
+func TestServer_UsersAndNewsletters(t *testing.T) {
+	srv, client := newServer(t)
+	srv.AddUser(libecto.Author{Name: "Jane Writer", Email: "jane@example.com"})
+
+	users, err := client.ListUsers()
+	require.NoError(t, err)
+	assert.Len(t, users.Users, 2)
+
+	jane, err := client.GetUser("jane-writer")
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", jane.Email)
+
+	post, err := client.CreatePost(&libecto.Post{Title: "By Jane", Authors: []libecto.Author{{Email: "jane@example.com"}}})
+	require.NoError(t, err)
+	assert.Equal(t, jane.ID, post.Authors[0].ID)
+
+	newsletters, err := client.ListNewsletters()
+	require.NoError(t, err)
+	require.Len(t, newsletters.Newsletters, 1)
+	assert.Equal(t, "default-newsletter", newsletters.Newsletters[0].Slug)
+
+	nl, err := client.GetNewsletter(newsletters.Newsletters[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", nl.Status)
+
+	_, err = client.GetNewsletter("000000000000000000000000")
+	assert.True(t, libecto.IsNotFound(err))
+}
+
+func TestServer_Webhooks(t *testing.T) {
+	srv, client := newServer(t)
+
+	hook, err := client.CreateWebhook(&libecto.Webhook{Event: "post.published", TargetURL: "https://example.com/hook"})
+	require.NoError(t, err)
+	assert.Equal(t, "available", hook.Status)
+
+	_, err = client.CreateWebhook(&libecto.Webhook{Event: "post.published"})
+	assert.True(t, libecto.IsValidation(err))
+
+	list, err := client.ListWebhooks()
+	require.NoError(t, err)
+	assert.Len(t, list.Webhooks, 1)
+
+	require.NoError(t, client.DeleteWebhook(hook.ID))
+	assert.Empty(t, srv.Webhooks())
+}
+
+func TestServer_ImageUpload(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	srv, client := newServer(t, WithClock(func() time.Time { return now }))
+
+	resp, err := client.UploadImageReader(strings.NewReader("\x89PNG\r\n\x1a\nfake"), "photo.png")
+	require.NoError(t, err)
+	url := resp.Images[0].URL
+	assert.Equal(t, srv.URL+"/content/images/2024/03/photo.png", url)
+
+	resp, err = client.UploadImageReader(strings.NewReader("again"), "photo.png")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/content/images/2024/03/photo-1.png", resp.Images[0].URL)
+
+	_, err = client.UploadImageReader(strings.NewReader("text"), "notes.txt")
+	require.Error(t, err)
+
+	uploads := srv.Uploads()
+	require.Len(t, uploads, 2)
+	assert.Equal(t, "photo.png", uploads[0].Filename)
+	assert.Equal(t, 12, uploads[0].Size)
+
+	get, err := http.Get(url)
+	require.NoError(t, err)
+	defer get.Body.Close()
+	data, _ := io.ReadAll(get.Body)
+	assert.Equal(t, "\x89PNG\r\n\x1a\nfake", string(data))
+	assert.Equal(t, "image/png", get.Header.Get("Content-Type"))
+}
+
+func TestServer_Authentication(t *testing.T) {
+	srv, _ := newServer(t)
+
+	wrongKey := libecto.NewClient(srv.URL, "abcdef:00112233445566778899aabbccddeeff", libecto.WithRetryPolicy(libecto.RetryPolicy{MaxAttempts: 1}))
+	_, err := wrongKey.ListPosts("", 0)
+	assert.True(t, libecto.IsUnauthorized(err))
+
+	keyID := strings.Split(srv.APIKey, ":")[0]
+	badSecret := libecto.NewClient(srv.URL, keyID+":00112233445566778899aabbccddeeff", libecto.WithRetryPolicy(libecto.RetryPolicy{MaxAttempts: 1}))
+	_, err = badSecret.ListPosts("", 0)
+	assert.True(t, libecto.IsUnauthorized(err))
+
+	expired, err := libecto.GenerateTokenWithTime(srv.APIKey, time.Now().Add(-10*time.Minute))
+	require.NoError(t, err)
+	old := libecto.NewClient(srv.URL, "", libecto.WithTokenSource(libecto.TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return expired, nil
+	})))
+	_, err = old.GetSite()
+	assert.True(t, libecto.IsUnauthorized(err))
+
+	req, _ := http.NewRequest("GET", srv.URL+"/ghost/api/admin/posts/", nil)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestServer_SiteAndSettings(t *testing.T) {
+	_, client := newServer(t, WithSite(libecto.Site{Title: "Custom", Version: "5.90"}), WithAPIKey("abc:0011"))
+
+	site, err := client.GetSite()
+	require.NoError(t, err)
+	assert.Equal(t, "Custom", site.Title)
+
+	ok, err := client.Supports(libecto.FeatureLexical)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	settings, err := client.GetSettings()
+	require.NoError(t, err)
+	assert.NotEmpty(t, settings.Settings)
+}
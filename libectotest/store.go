@@ -0,0 +1,536 @@
+package libectotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// item is a stored resource in its JSON object form.
+type item = map[string]interface{}
+
+// collection is an ordered set of resources of one type.
+type collection struct {
+	name  string
+	items []item
+}
+
+func newCollection(name string) *collection {
+	return &collection{name: name}
+}
+
+// ordered returns the items in the order Ghost lists them by default:
+// newest first for posts and pages, oldest first for everything else.
+func (c *collection) ordered() []item {
+	out := make([]item, len(c.items))
+	if c.name == "posts" || c.name == "pages" {
+		for i, it := range c.items {
+			out[len(c.items)-1-i] = it
+		}
+		return out
+	}
+	copy(out, c.items)
+	return out
+}
+
+// find returns the first item whose field equals value, or nil.
+func (c *collection) find(field, value string) item {
+	for _, it := range c.items {
+		if s, _ := it[field].(string); s == value {
+			return it
+		}
+	}
+	return nil
+}
+
+// remove deletes it from the collection.
+func (c *collection) remove(it item) {
+	for i, candidate := range c.items {
+		if candidate["id"] == it["id"] {
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			return
+		}
+	}
+}
+
+// readOnlyFields are computed by Ghost and stripped from incoming posts and pages,
+// so clients can send back a resource exactly as they received it.
+var readOnlyFields = map[string]bool{
+	"url": true, "primary_tag": true, "primary_author": true, "reading_time": true,
+	"excerpt": true, "email": true, "newsletter": true, "count": true,
+}
+
+// create validates and stores a new item, filling in server-generated fields.
+func (s *Server) create(c *collection, in item) (item, *apiError) {
+	it := item{}
+	for k, v := range in {
+		if readOnlyFields[k] && (c == s.posts || c == s.pages) {
+			continue
+		}
+		it[k] = v
+	}
+	now := s.timestamp()
+	it["id"] = s.newID()
+	it["created_at"] = now
+	it["updated_at"] = now
+
+	switch c {
+	case s.posts, s.pages:
+		it["uuid"] = newUUID()
+		title, _ := it["title"].(string)
+		if title == "" {
+			title = "(Untitled)"
+			it["title"] = title
+		}
+		setDefault(it, "status", "draft")
+		setDefault(it, "visibility", "public")
+		if c == s.posts {
+			setDefault(it, "featured", false)
+		}
+		if apiErr := s.applyStatus(it, now); apiErr != nil {
+			return nil, apiErr
+		}
+		if apiErr := s.applyRelations(it); apiErr != nil {
+			return nil, apiErr
+		}
+		if _, ok := it["authors"]; !ok && len(s.users.items) > 0 {
+			it["authors"] = []interface{}{s.users.items[0]["id"]}
+		}
+		it["slug"] = s.uniqueSlug(c, slugSource(it, title), "")
+
+	case s.tags:
+		name, _ := it["name"].(string)
+		if name == "" {
+			return nil, errValidation("Validation error, cannot save tag. Tag name is required.")
+		}
+		if strings.HasPrefix(name, "#") {
+			it["visibility"] = "internal"
+		}
+		setDefault(it, "visibility", "public")
+		it["slug"] = s.uniqueSlug(c, slugSource(it, strings.TrimPrefix(name, "#")), "")
+
+	case s.users:
+		name, _ := it["name"].(string)
+		it["slug"] = s.uniqueSlug(c, slugSource(it, name), "")
+		setDefault(it, "status", "active")
+
+	case s.newsletters:
+		name, _ := it["name"].(string)
+		if name == "" {
+			return nil, errValidation("Validation error, cannot save newsletter. Name is required.")
+		}
+		it["slug"] = s.uniqueSlug(c, slugSource(it, name), "")
+		setDefault(it, "status", "active")
+		setDefault(it, "sender_reply_to", "newsletter")
+
+	case s.webhooks:
+		event, _ := it["event"].(string)
+		target, _ := it["target_url"].(string)
+		if event == "" || target == "" {
+			return nil, errValidation("Validation error, cannot save webhook. Event and target_url are required.")
+		}
+		setDefault(it, "status", "available")
+	}
+
+	c.items = append(c.items, it)
+	return it, nil
+}
+
+// update applies the fields in in to it. Posts and pages must supply the
+// current updated_at, as Ghost uses it to detect conflicting edits.
+func (s *Server) update(c *collection, it, in item) (item, *apiError) {
+	if c == s.posts || c == s.pages {
+		given, ok := in["updated_at"].(string)
+		if !ok || given == "" {
+			return nil, &apiError{
+				status:  http.StatusUnprocessableEntity,
+				Message: "Validation error, cannot edit " + strings.TrimSuffix(c.name, "s") + ".",
+				Context: "Value in [" + c.name + ".updated_at] cannot be blank.",
+				Type:    "ValidationError",
+			}
+		}
+		if given != it["updated_at"] {
+			return nil, errCollision()
+		}
+	}
+	if c == s.tags {
+		if name, ok := in["name"]; ok && name == "" {
+			return nil, errValidation("Validation error, cannot edit tag. Tag name is required.")
+		}
+	}
+
+	next := item{}
+	for k, v := range it {
+		next[k] = v
+	}
+	for k, v := range in {
+		switch k {
+		case "id", "uuid", "created_at", "updated_at":
+			continue
+		}
+		if readOnlyFields[k] && (c == s.posts || c == s.pages) {
+			continue
+		}
+		next[k] = v
+	}
+
+	now := s.timestamp()
+	if c == s.posts || c == s.pages {
+		if apiErr := s.applyStatus(next, now); apiErr != nil {
+			return nil, apiErr
+		}
+		if apiErr := s.applyRelations(next); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+	if slug, ok := in["slug"].(string); ok && slug != it["slug"] {
+		next["slug"] = s.uniqueSlug(c, slug, it["id"].(string))
+	}
+	next["updated_at"] = now
+
+	for k := range it {
+		delete(it, k)
+	}
+	for k, v := range next {
+		it[k] = v
+	}
+	return it, nil
+}
+
+// destroy removes it, detaching deleted tags from posts and pages.
+func (s *Server) destroy(c *collection, it item) {
+	c.remove(it)
+	if c != s.tags {
+		return
+	}
+	for _, owner := range [...]*collection{s.posts, s.pages} {
+		for _, p := range owner.items {
+			ids, _ := p["tags"].([]interface{})
+			kept := ids[:0:0]
+			for _, id := range ids {
+				if id != it["id"] {
+					kept = append(kept, id)
+				}
+			}
+			p["tags"] = kept
+		}
+	}
+}
+
+// applyStatus validates the status and fills in published_at when publishing.
+func (s *Server) applyStatus(it item, now string) *apiError {
+	status, _ := it["status"].(string)
+	switch status {
+	case "draft":
+	case "published":
+		if at, _ := it["published_at"].(string); at == "" {
+			it["published_at"] = now
+		}
+	case "scheduled":
+		if at, _ := it["published_at"].(string); at == "" {
+			return errValidation("Validation error, cannot schedule without a published_at date.")
+		}
+	default:
+		return errValidation(fmt.Sprintf("Validation error, status must be one of draft, published, scheduled. Got %q.", status))
+	}
+	return nil
+}
+
+// applyRelations replaces embedded tags and authors with stored IDs.
+// Tags are matched by id, slug or name and created when missing, as Ghost does.
+func (s *Server) applyRelations(it item) *apiError {
+	if raw, ok := it["tags"].([]interface{}); ok {
+		ids := make([]interface{}, 0, len(raw))
+		for _, ref := range raw {
+			tag := s.resolve(s.tags, ref)
+			if tag == nil {
+				name := refName(ref)
+				if name == "" {
+					return errValidation("Validation error, tag reference is invalid.")
+				}
+				var apiErr *apiError
+				if tag, apiErr = s.create(s.tags, item{"name": name}); apiErr != nil {
+					return apiErr
+				}
+			}
+			ids = append(ids, tag["id"])
+		}
+		it["tags"] = ids
+	}
+	if raw, ok := it["authors"].([]interface{}); ok {
+		ids := make([]interface{}, 0, len(raw))
+		for _, ref := range raw {
+			user := s.resolve(s.users, ref)
+			if user == nil {
+				return errValidation("Validation error, author does not exist.")
+			}
+			ids = append(ids, user["id"])
+		}
+		it["authors"] = ids
+	}
+	return nil
+}
+
+// resolve finds the item referenced by an ID string or an object with id, slug, name or email.
+func (s *Server) resolve(c *collection, ref interface{}) item {
+	switch r := ref.(type) {
+	case string:
+		if found := c.find("id", r); found != nil {
+			return found
+		}
+		if found := c.find("slug", r); found != nil {
+			return found
+		}
+		return c.find("name", r)
+	case map[string]interface{}:
+		for _, field := range [...]string{"id", "slug", "name", "email"} {
+			if v, ok := r[field].(string); ok && v != "" {
+				if found := c.find(field, v); found != nil {
+					return found
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func refName(ref interface{}) string {
+	switch r := ref.(type) {
+	case string:
+		return r
+	case map[string]interface{}:
+		name, _ := r["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// render returns the API representation of it with relations expanded.
+func (s *Server) render(c *collection, it item) item {
+	out := item{}
+	for k, v := range it {
+		out[k] = v
+	}
+	switch c {
+	case s.posts, s.pages:
+		tags := s.expand(s.tags, it["tags"])
+		authors := s.expand(s.users, it["authors"])
+		out["tags"] = tags
+		out["authors"] = authors
+		out["primary_tag"] = first(tags)
+		out["primary_author"] = first(authors)
+		out["url"] = s.URL + "/" + it["slug"].(string) + "/"
+	case s.tags:
+		out["count"] = map[string]interface{}{"posts": s.countPosts(it["id"])}
+	}
+	return out
+}
+
+func (s *Server) renderAll(c *collection, items []item) []item {
+	out := make([]item, len(items))
+	for i, it := range items {
+		out[i] = s.render(c, it)
+	}
+	return out
+}
+
+func (s *Server) expand(c *collection, ids interface{}) []interface{} {
+	list, _ := ids.([]interface{})
+	out := make([]interface{}, 0, len(list))
+	for _, id := range list {
+		if found, ok := id.(string); ok {
+			if it := c.find("id", found); it != nil {
+				out = append(out, s.render(c, it))
+			}
+		}
+	}
+	return out
+}
+
+func (s *Server) countPosts(tagID interface{}) int {
+	n := 0
+	for _, p := range s.posts.items {
+		ids, _ := p["tags"].([]interface{})
+		for _, id := range ids {
+			if id == tagID {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+func first(list []interface{}) interface{} {
+	if len(list) == 0 {
+		return nil
+	}
+	return list[0]
+}
+
+// browse lists a collection with filter and pagination support.
+func (s *Server) browse(r *http.Request, c *collection) (int, interface{}, *apiError) {
+	q := r.URL.Query()
+	f, apiErr := parseFilter(q.Get("filter"))
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+
+	var matched []item
+	for _, it := range c.ordered() {
+		rendered := s.render(c, it)
+		if f.match(rendered) {
+			matched = append(matched, rendered)
+		}
+	}
+
+	page := 1
+	if v := q.Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, nil, errBadRequest("Validation (isInt) failed for page", "")
+		}
+		page = n
+	}
+
+	total := len(matched)
+	var limit interface{} = 15
+	perPage := 15
+	switch v := q.Get("limit"); v {
+	case "":
+	case "all":
+		limit, perPage = "all", total
+	default:
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, nil, errBadRequest("Validation (isInt) failed for limit", "")
+		}
+		limit, perPage = n, n
+	}
+
+	pages := 1
+	if perPage > 0 && total > 0 {
+		pages = int(math.Ceil(float64(total) / float64(perPage)))
+	}
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total || limit == "all" {
+		end = total
+	}
+
+	var next, prev interface{}
+	if page < pages {
+		next = page + 1
+	}
+	if page > 1 {
+		prev = page - 1
+	}
+
+	items := matched[start:end]
+	if items == nil {
+		items = []item{}
+	}
+	return http.StatusOK, map[string]interface{}{
+		c.name: items,
+		"meta": map[string]interface{}{
+			"pagination": map[string]interface{}{
+				"page":  page,
+				"limit": limit,
+				"pages": pages,
+				"total": total,
+				"next":  next,
+				"prev":  prev,
+			},
+		},
+	}, nil
+}
+
+// decodeSingle reads a {"<root>": [{...}]} request body.
+func decodeSingle(r *http.Request, root string) (item, *apiError) {
+	var body map[string][]item
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, errBadRequest("Request body is not valid JSON.", err.Error())
+	}
+	list, ok := body[root]
+	if !ok || len(list) != 1 || list[0] == nil {
+		return nil, errValidation(fmt.Sprintf("No root key ('%s') provided.", root))
+	}
+	return list[0], nil
+}
+
+// uniqueSlug slugifies base and appends -2, -3, ... until no other item uses it.
+func (s *Server) uniqueSlug(c *collection, base, selfID string) string {
+	slug := slugify(base)
+	if slug == "" {
+		slug = "untitled"
+	}
+	candidate := slug
+	for i := 2; ; i++ {
+		existing := c.find("slug", candidate)
+		if existing == nil || existing["id"] == selfID {
+			return candidate
+		}
+		candidate = slug + "-" + strconv.Itoa(i)
+	}
+}
+
+// slugSource returns the requested slug, or fallback when none was given.
+func slugSource(it item, fallback string) string {
+	if slug, _ := it["slug"].(string); slug != "" {
+		return slug
+	}
+	return fallback
+}
+
+// slugify lowercases s and replaces runs of non-alphanumerics with hyphens.
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+func setDefault(it item, key string, value interface{}) {
+	if v, ok := it[key]; !ok || v == nil || v == "" {
+		it[key] = value
+	}
+}
+
+// toItem converts a libecto value to its JSON object form.
+func toItem(v interface{}) item {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic("libectotest: " + err.Error())
+	}
+	var it item
+	if err := json.Unmarshal(data, &it); err != nil {
+		panic("libectotest: " + err.Error())
+	}
+	return it
+}
+
+// fromItem decodes JSON-compatible data into out.
+func fromItem(v interface{}, out interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic("libectotest: " + err.Error())
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		panic("libectotest: " + err.Error())
+	}
+}
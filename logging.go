@@ -0,0 +1,180 @@
+package libecto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces secret values in log output.
+const redacted = "[REDACTED]"
+
+// sensitiveKeys lists JSON fields and query parameters whose values are never logged.
+var sensitiveKeys = map[string]bool{
+	"secret":        true,
+	"password":      true,
+	"token":         true,
+	"key":           true,
+	"api_key":       true,
+	"admin_api_key": true,
+	"authorization": true,
+}
+
+// WithLogger enables structured logging of API calls to logger.
+// Each HTTP attempt is logged with its method, path, status, duration,
+// attempt number and Ghost error type. Authorization headers are never
+// logged and secrets in paths and bodies are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithBodyLogging additionally logs request and response bodies at debug level.
+// It has no effect unless a logger is set with WithLogger.
+func WithBodyLogging(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.logBody = enabled
+	}
+}
+
+// logAttempt records the outcome of a single HTTP attempt.
+func (c *Client) logAttempt(ctx context.Context, method, path string, attempt int, duration time.Duration, resp *Response, err error, retry bool, delay time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("path", redactPath(path)),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	}
+	level := slog.LevelInfo
+	msg := "ghost api request"
+
+	if err != nil {
+		level = slog.LevelError
+		msg = "ghost api request failed"
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			level = slog.LevelWarn
+			if errType := newResponseError(resp.StatusCode, method, path, resp.Body).Type(); errType != "" {
+				attrs = append(attrs, slog.String("error_type", errType))
+			}
+		}
+	}
+	if retry {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.Bool("retrying", true), slog.Duration("retry_delay", delay))
+	}
+
+	c.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logBodies records request and response bodies when body logging is enabled.
+func (c *Client) logBodies(ctx context.Context, method, path, contentType string, body []byte, resp *Response) {
+	if c.logger == nil || !c.logBody || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("path", redactPath(path)),
+	}
+	if body != nil {
+		attrs = append(attrs, slog.String("request_body", redactBody(contentType, body)))
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.String("response_body", redactBody(resp.Header.Get("Content-Type"), resp.Body)))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "ghost api body", attrs...)
+}
+
+// logRateLimitWait records time spent waiting for the rate limiter.
+func (c *Client) logRateLimitWait(ctx context.Context, method, path string, wait time.Duration) {
+	if c.logger == nil || wait <= 0 {
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "ghost api rate limited",
+		slog.String("method", method),
+		slog.String("path", redactPath(path)),
+		slog.Duration("wait", wait),
+	)
+}
+
+// redactPath hides the values of sensitive query parameters.
+func redactPath(path string) string {
+	base, query, ok := strings.Cut(path, "?")
+	if !ok {
+		return path
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return base + "?" + redacted
+	}
+	changed := false
+	for name := range values {
+		if sensitiveKeys[strings.ToLower(name)] {
+			values[name] = []string{redacted}
+			changed = true
+		}
+	}
+	if !changed {
+		return path
+	}
+	return base + "?" + values.Encode()
+}
+
+// redactBody renders a body for logging, hiding sensitive JSON fields.
+// Non-JSON bodies such as multipart uploads are summarized by size.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if json.Unmarshal(body, &v) != nil {
+		if strings.HasPrefix(contentType, "multipart/") || !isText(body) {
+			return fmt.Sprintf("<%d bytes %s>", len(body), contentType)
+		}
+		return string(body)
+	}
+	data, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redacted
+	}
+	return string(data)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if sensitiveKeys[strings.ToLower(k)] {
+				val[k] = redacted
+			} else {
+				val[k] = redactValue(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(child)
+		}
+	}
+	return v
+}
+
+func isText(body []byte) bool {
+	for _, b := range body {
+		if b < 0x09 || (b > 0x0d && b < 0x20) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package libecto
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(level slog.Level) (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level})), &buf
+}
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"posts":[]}`))
+	}))
+	defer server.Close()
+
+	logger, buf := newTestLogger(slog.LevelInfo)
+	client := NewClient(server.URL, testAPIKey, WithLogger(logger))
+	_, err := client.ListPosts("published", 5)
+	require.NoError(t, err)
+
+	records := logRecords(t, buf)
+	require.Len(t, records, 1)
+	rec := records[0]
+	assert.Equal(t, "INFO", rec["level"])
+	assert.Equal(t, "ghost api request", rec["msg"])
+	assert.Equal(t, "GET", rec["method"])
+	assert.Contains(t, rec["path"], "/posts/")
+	assert.Equal(t, float64(200), rec["status"])
+	assert.Equal(t, float64(1), rec["attempt"])
+	assert.Contains(t, rec, "duration")
+	assert.NotContains(t, buf.String(), "Ghost ey", "authorization token must not be logged")
+}
+
+func TestClient_WithLogger_ErrorType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{Message: "Post not found", Type: ErrorTypeNotFound}}})
+	}))
+	defer server.Close()
+
+	logger, buf := newTestLogger(slog.LevelInfo)
+	client := NewClient(server.URL, testAPIKey, WithLogger(logger))
+	err := client.DeletePost("missing")
+	require.Error(t, err)
+
+	records := logRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, float64(404), records[0]["status"])
+	assert.Equal(t, ErrorTypeNotFound, records[0]["error_type"])
+}
+
+func TestClient_WithLogger_Retries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"site":{}}`))
+	}))
+	defer server.Close()
+
+	logger, buf := newTestLogger(slog.LevelInfo)
+	client := NewClient(server.URL, testAPIKey, WithLogger(logger),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}))
+	_, err := client.GetSite()
+	require.NoError(t, err)
+
+	records := logRecords(t, buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, true, records[0]["retrying"])
+	assert.Equal(t, float64(1), records[0]["attempt"])
+	assert.Equal(t, "INFO", records[1]["level"])
+	assert.Equal(t, float64(2), records[1]["attempt"])
+}
+
+func TestClient_WithLogger_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	logger, buf := newTestLogger(slog.LevelInfo)
+	client := NewClient(url, testAPIKey, WithLogger(logger))
+	_, err := client.GetSite()
+	require.Error(t, err)
+
+	records := logRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "ERROR", records[0]["level"])
+	assert.Equal(t, "ghost api request failed", records[0]["msg"])
+	assert.Contains(t, records[0], "error")
+}
+
+func TestClient_WithBodyLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"webhooks":[{"id":"1","secret":"returned-secret"}]}`))
+	}))
+	defer server.Close()
+
+	logger, buf := newTestLogger(slog.LevelDebug)
+	client := NewClient(server.URL, testAPIKey, WithLogger(logger), WithBodyLogging(true))
+	_, err := client.CreateWebhook(&Webhook{Event: "post.published", Secret: "sent-secret"})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "ghost api body")
+	assert.Contains(t, out, "post.published")
+	assert.NotContains(t, out, "sent-secret")
+	assert.NotContains(t, out, "returned-secret")
+	assert.Contains(t, out, redacted)
+}
+
+func TestClient_WithBodyLogging_DisabledAtInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"site":{}}`))
+	}))
+	defer server.Close()
+
+	logger, buf := newTestLogger(slog.LevelInfo)
+	client := NewClient(server.URL, testAPIKey, WithLogger(logger), WithBodyLogging(true))
+	_, err := client.GetSite()
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "ghost api body")
+}
+
+func TestClient_WithLogger_RateLimitWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"site":{}}`))
+	}))
+	defer server.Close()
+
+	logger, buf := newTestLogger(slog.LevelDebug)
+	client := NewClient(server.URL, testAPIKey, WithLogger(logger), WithRateLimit(100, 1))
+	for i := 0; i < 2; i++ {
+		_, err := client.GetSite()
+		require.NoError(t, err)
+	}
+	assert.Contains(t, buf.String(), "ghost api rate limited")
+}
+
+func TestRedactPath(t *testing.T) {
+	assert.Equal(t, "/posts/", redactPath("/posts/"))
+	assert.Equal(t, "/posts/?limit=5", redactPath("/posts/?limit=5"))
+	assert.Equal(t, "/posts/?key=%5BREDACTED%5D&limit=5", redactPath("/posts/?key=abc123&limit=5"))
+	assert.Equal(t, "/posts/?"+redacted, redactPath("/posts/?%zz"))
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.Equal(t, "", redactBody("application/json", nil))
+	assert.JSONEq(t,
+		`{"webhooks":[{"secret":"[REDACTED]","target_url":"https://x"}],"password":"[REDACTED]"}`,
+		redactBody("application/json", []byte(`{"webhooks":[{"secret":"s","target_url":"https://x"}],"password":"p"}`)))
+	assert.Equal(t, "Bad Gateway", redactBody("text/plain", []byte("Bad Gateway")))
+	assert.Equal(t, "<5 bytes multipart/form-data>", redactBody("multipart/form-data", []byte("--abc")))
+	assert.Equal(t, "<3 bytes image/png>", redactBody("image/png", []byte{0x89, 0x00, 0x01}))
+}
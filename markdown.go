@@ -1,9 +1,22 @@
 package libecto
 
 import (
+	"bytes"
+	"html"
+	"strings"
+
 	"github.com/russross/blackfriday/v2"
+
+	"github.com/visionik/libecto/lexical"
+	"github.com/visionik/libecto/mobiledoc"
 )
 
+// markdownExtensions are the blackfriday extensions used by all markdown
+// conversions in this package.
+const markdownExtensions = blackfriday.CommonExtensions |
+	blackfriday.AutoHeadingIDs |
+	blackfriday.NoEmptyLineBeforeBlock
+
 // MarkdownToHTML converts markdown content to HTML.
 // It uses blackfriday with common extensions including:
 //   - CommonExtensions (tables, fenced code, autolinks, strikethrough, etc.)
@@ -14,13 +27,7 @@ import (
 // any embedded code, though the output should still be sanitized if
 // displayed in a web context.
 func MarkdownToHTML(md []byte) string {
-	html := blackfriday.Run(md,
-		blackfriday.WithExtensions(
-			blackfriday.CommonExtensions|
-				blackfriday.AutoHeadingIDs|
-				blackfriday.NoEmptyLineBeforeBlock,
-		),
-	)
+	html := blackfriday.Run(md, blackfriday.WithExtensions(markdownExtensions))
 	return string(html)
 }
 
@@ -30,3 +37,315 @@ func MarkdownToHTML(md []byte) string {
 func MarkdownStringToHTML(md string) string {
 	return MarkdownToHTML([]byte(md))
 }
+
+// MarkdownToLexical converts markdown content to a Lexical document that
+// renders natively in the Ghost editor, parsing it with the same extensions
+// as MarkdownToHTML:
+//   - paragraphs, lists, quotes, links and emphasis become Lexical nodes
+//   - fenced code blocks become code cards, keeping their language
+//   - images on their own become image cards, with the image title as caption
+//   - headings become heading nodes; Ghost derives their IDs from the text as
+//     AutoHeadingIDs does, so a heading with an explicit {#id} becomes an
+//     HTML card to keep it
+//   - tables, definition lists, raw HTML and anything Lexical cannot
+//     represent become HTML cards rendered as MarkdownToHTML would
+//
+// Set the result on a post with Post.SetLexical.
+func MarkdownToLexical(md []byte) *lexical.Document {
+	// Without AutoHeadingIDs only explicit heading IDs are set, which is how
+	// they are told apart; the extension does not otherwise affect the tree.
+	parser := blackfriday.New(blackfriday.WithExtensions(markdownExtensions &^ blackfriday.AutoHeadingIDs))
+	root := parser.Parse(md)
+
+	c := &lexicalConverter{
+		html: blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{Flags: blackfriday.CommonHTMLFlags}),
+		sp:   blackfriday.NewSmartypantsRenderer(blackfriday.CommonHTMLFlags),
+	}
+	doc := lexical.New()
+	for n := root.FirstChild; n != nil; n = n.Next {
+		doc.Append(c.block(n)...)
+	}
+	return doc
+}
+
+// MarkdownStringToLexical is like MarkdownToLexical but takes a string.
+func MarkdownStringToLexical(md string) *lexical.Document {
+	return MarkdownToLexical([]byte(md))
+}
+
+// MarkdownToMobiledoc converts markdown content to a Mobiledoc document for
+// Ghost 4 and earlier. Blocks map to sections and cards as in
+// MarkdownToLexical; nested lists are flattened, as Mobiledoc lists cannot
+// nest. Set the result on a post with Post.SetMobiledoc.
+func MarkdownToMobiledoc(md []byte) *mobiledoc.Document {
+	// FromLexical only fails on card payloads JSON cannot encode, which the
+	// markdown converter never produces.
+	doc, _ := mobiledoc.FromLexical(MarkdownToLexical(md))
+	return doc
+}
+
+// MarkdownStringToMobiledoc is like MarkdownToMobiledoc but takes a string.
+func MarkdownStringToMobiledoc(md string) *mobiledoc.Document {
+	return MarkdownToMobiledoc([]byte(md))
+}
+
+// lexicalConverter converts a blackfriday syntax tree to Lexical nodes.
+type lexicalConverter struct {
+	html *blackfriday.HTMLRenderer
+	sp   *blackfriday.SPRenderer
+}
+
+// block converts a block-level node.
+func (c *lexicalConverter) block(n *blackfriday.Node) []lexical.Node {
+	switch n.Type {
+	case blackfriday.Paragraph:
+		return c.paragraph(n)
+	case blackfriday.Heading:
+		if n.HeadingID != "" || contains(n, blackfriday.HTMLSpan, blackfriday.Image) {
+			break
+		}
+		return []lexical.Node{lexical.NewHeading(n.Level, c.inlines(n, 0)...)}
+	case blackfriday.BlockQuote:
+		if quote := c.quote(n); quote != nil {
+			return []lexical.Node{quote}
+		}
+	case blackfriday.List:
+		if list := c.list(n); list != nil {
+			return []lexical.Node{list}
+		}
+	case blackfriday.CodeBlock:
+		language := ""
+		if fields := strings.Fields(string(n.Info)); len(fields) > 0 {
+			language = fields[0]
+		}
+		return []lexical.Node{lexical.NewCodeBlock(strings.TrimSuffix(string(n.Literal), "\n"), language)}
+	case blackfriday.HorizontalRule:
+		return []lexical.Node{lexical.NewHorizontalRule()}
+	case blackfriday.HTMLBlock:
+		return []lexical.Node{lexical.NewHTML(strings.TrimRight(string(n.Literal), "\n"))}
+	}
+	return []lexical.Node{lexical.NewHTML(c.renderHTML(n))}
+}
+
+// paragraph converts a paragraph, lifting images out into image cards.
+func (c *lexicalConverter) paragraph(n *blackfriday.Node) []lexical.Node {
+	if contains(n, blackfriday.HTMLSpan) {
+		return []lexical.Node{lexical.NewHTML(c.renderHTML(n))}
+	}
+	var out, inline []lexical.Node
+	flush := func() {
+		if hasText(inline) {
+			out = append(out, lexical.NewParagraph(inline...))
+		}
+		inline = nil
+	}
+	for child := n.FirstChild; child != nil; child = child.Next {
+		if card := imageCard(child); card != nil {
+			flush()
+			out = append(out, card)
+			continue
+		}
+		inline = append(inline, c.inline(child, 0)...)
+	}
+	flush()
+	return out
+}
+
+// quote converts a block quote of paragraphs, or returns nil if it holds
+// other blocks.
+func (c *lexicalConverter) quote(n *blackfriday.Node) *lexical.Quote {
+	if contains(n, blackfriday.HTMLSpan, blackfriday.Image) {
+		return nil
+	}
+	quote := lexical.NewQuote()
+	for p := n.FirstChild; p != nil; p = p.Next {
+		if p.Type != blackfriday.Paragraph {
+			return nil
+		}
+		if p != n.FirstChild {
+			quote.Append(&lexical.LineBreak{}, &lexical.LineBreak{})
+		}
+		quote.Append(c.inlines(p, 0)...)
+	}
+	return quote
+}
+
+// list converts a bulleted or numbered list, or returns nil if an item holds
+// blocks Lexical list items cannot.
+func (c *lexicalConverter) list(n *blackfriday.Node) *lexical.List {
+	if n.ListFlags&blackfriday.ListTypeDefinition != 0 || contains(n, blackfriday.HTMLSpan, blackfriday.Image) {
+		return nil
+	}
+	var items []*lexical.ListItem
+	for item := n.FirstChild; item != nil; item = item.Next {
+		li := lexical.NewListItem()
+		var nested []*lexical.ListItem
+		for child := item.FirstChild; child != nil; child = child.Next {
+			switch child.Type {
+			case blackfriday.Paragraph:
+				if len(li.Children) > 0 {
+					li.Append(&lexical.LineBreak{})
+				}
+				li.Append(c.inlines(child, 0)...)
+			case blackfriday.List:
+				sub := c.list(child)
+				if sub == nil {
+					return nil
+				}
+				// Lexical nests a list in an item of its own.
+				nested = append(nested, lexical.NewListItem(sub))
+			default:
+				return nil
+			}
+		}
+		items = append(items, li)
+		items = append(items, nested...)
+	}
+	if n.ListFlags&blackfriday.ListTypeOrdered != 0 {
+		return lexical.NewNumberedList(items...)
+	}
+	return lexical.NewBulletList(items...)
+}
+
+// inlines converts the children of n with the given format.
+func (c *lexicalConverter) inlines(n *blackfriday.Node, format lexical.TextFormat) []lexical.Node {
+	var out []lexical.Node
+	for child := n.FirstChild; child != nil; child = child.Next {
+		out = append(out, c.inline(child, format)...)
+	}
+	return mergeText(out)
+}
+
+// inline converts an inline node, adding format to any text it holds.
+func (c *lexicalConverter) inline(n *blackfriday.Node, format lexical.TextFormat) []lexical.Node {
+	switch n.Type {
+	case blackfriday.Text:
+		text := strings.ReplaceAll(c.smarten(n.Literal), "\n", " ")
+		if text == "" {
+			return nil
+		}
+		return []lexical.Node{lexical.NewFormattedText(text, format)}
+	case blackfriday.Code:
+		return []lexical.Node{lexical.NewFormattedText(string(n.Literal), format|lexical.FormatCode)}
+	case blackfriday.Emph:
+		return c.inlines(n, format|lexical.FormatItalic)
+	case blackfriday.Strong:
+		return c.inlines(n, format|lexical.FormatBold)
+	case blackfriday.Del:
+		return c.inlines(n, format|lexical.FormatStrikethrough)
+	case blackfriday.Link:
+		link := lexical.NewLink(string(n.Destination), c.inlines(n, format)...)
+		link.Title = string(n.Title)
+		return []lexical.Node{link}
+	case blackfriday.Hardbreak:
+		return []lexical.Node{&lexical.LineBreak{}}
+	case blackfriday.Softbreak:
+		return []lexical.Node{lexical.NewFormattedText(" ", format)}
+	}
+	// Images that are not cards, such as those inside links with other
+	// content, are reduced to their alt text.
+	return c.inlines(n, format)
+}
+
+// smarten applies the smart punctuation MarkdownToHTML uses to text.
+func (c *lexicalConverter) smarten(text []byte) string {
+	var out bytes.Buffer
+	c.sp.Process(&out, []byte(htmlEscaper.Replace(string(text))))
+	return html.UnescapeString(out.String())
+}
+
+// htmlEscaper escapes text the way blackfriday does before applying smart
+// punctuation, which recognizes &quot; rather than &#34;.
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// renderHTML renders n and its children as MarkdownToHTML would.
+func (c *lexicalConverter) renderHTML(n *blackfriday.Node) string {
+	var buf bytes.Buffer
+	n.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		return c.html.RenderNode(&buf, node, entering)
+	})
+	return strings.TrimSpace(buf.String())
+}
+
+// imageCard returns an image card for an image, or a link wrapping only an
+// image, and nil for any other node.
+func imageCard(n *blackfriday.Node) *lexical.Image {
+	href := ""
+	if n.Type == blackfriday.Link {
+		// blackfriday leaves empty text nodes around inline content.
+		var only *blackfriday.Node
+		for child := n.FirstChild; child != nil; child = child.Next {
+			if child.Type == blackfriday.Text && len(child.Literal) == 0 {
+				continue
+			}
+			if only != nil {
+				return nil
+			}
+			only = child
+		}
+		if only == nil {
+			return nil
+		}
+		href = string(n.Destination)
+		n = only
+	}
+	if n.Type != blackfriday.Image {
+		return nil
+	}
+	card := lexical.NewImage(string(n.Destination), plainText(n), html.EscapeString(string(n.Title)))
+	card.Href = href
+	return card
+}
+
+// plainText returns the text content of n's children.
+func plainText(n *blackfriday.Node) string {
+	var b strings.Builder
+	n.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if entering && (node.Type == blackfriday.Text || node.Type == blackfriday.Code) {
+			b.Write(node.Literal)
+		}
+		return blackfriday.GoToNext
+	})
+	return b.String()
+}
+
+// contains reports whether n or any node below it has one of the types.
+func contains(n *blackfriday.Node, types ...blackfriday.NodeType) bool {
+	found := false
+	n.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		for _, t := range types {
+			if node.Type == t {
+				found = true
+				return blackfriday.Terminate
+			}
+		}
+		return blackfriday.GoToNext
+	})
+	return found
+}
+
+// hasText reports whether nodes contain anything besides whitespace.
+func hasText(nodes []lexical.Node) bool {
+	for _, n := range nodes {
+		if t, ok := n.(*lexical.Text); !ok || strings.TrimSpace(t.Text) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeText joins adjacent text nodes with the same format, which blackfriday
+// splits around punctuation.
+func mergeText(nodes []lexical.Node) []lexical.Node {
+	var out []lexical.Node
+	for _, n := range nodes {
+		if t, ok := n.(*lexical.Text); ok && len(out) > 0 {
+			if prev, ok := out[len(out)-1].(*lexical.Text); ok && prev.Format == t.Format {
+				out[len(out)-1] = lexical.NewFormattedText(prev.Text+t.Text, t.Format)
+				continue
+			}
+		}
+		out = append(out, n)
+	}
+	return out
+}
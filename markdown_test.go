@@ -1,10 +1,14 @@
 package libecto
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/visionik/libecto/lexical"
 )
 
 func TestMarkdownToHTML(t *testing.T) {
@@ -280,6 +284,125 @@ func TestMarkdownToHTML_NestedStructures(t *testing.T) {
 	}
 }
 
+func TestMarkdownToLexical(t *testing.T) {
+	doc := MarkdownStringToLexical("# Title\n\n" +
+		"Some \"quoted\" *italic* and **bold `code`** [link](https://example.com \"Tip\").  \nNext\n\n" +
+		"```go\nfmt.Println(1)\n```\n\n" +
+		"---\n")
+	require.Len(t, doc.Children, 4)
+
+	h := doc.Children[0].(*lexical.Heading)
+	assert.Equal(t, 1, h.Level())
+	assert.Equal(t, "Title", h.Children[0].(*lexical.Text).Text)
+
+	p := doc.Children[1].(*lexical.Paragraph)
+	texts := []string{}
+	formats := []lexical.TextFormat{}
+	for _, n := range p.Children {
+		if text, ok := n.(*lexical.Text); ok {
+			texts = append(texts, text.Text)
+			formats = append(formats, text.Format)
+		}
+	}
+	assert.Equal(t, []string{"Some \u201cquoted\u201d ", "italic", " and ", "bold ", "code", " ", ".", "Next"}, texts)
+	assert.Equal(t, []lexical.TextFormat{0, lexical.FormatItalic, 0, lexical.FormatBold,
+		lexical.FormatBold | lexical.FormatCode, 0, 0, 0}, formats)
+	link := p.Children[6].(*lexical.Link)
+	assert.Equal(t, "https://example.com", link.URL)
+	assert.Equal(t, "Tip", link.Title)
+	assert.IsType(t, &lexical.LineBreak{}, p.Children[8])
+
+	code := doc.Children[2].(*lexical.CodeBlock)
+	assert.Equal(t, "fmt.Println(1)", code.Code)
+	assert.Equal(t, "go", code.Language)
+	assert.IsType(t, &lexical.HorizontalRule{}, doc.Children[3])
+}
+
+func TestMarkdownToLexical_Images(t *testing.T) {
+	doc := MarkdownStringToLexical("![A cat](/cat.png \"Our <cat>\")\n\n" +
+		"[![Logo](/logo.png)](https://example.com)\n\n" +
+		"Before ![inline](/i.png) after\n")
+	require.Len(t, doc.Children, 5)
+
+	img := doc.Children[0].(*lexical.Image)
+	assert.Equal(t, "/cat.png", img.Src)
+	assert.Equal(t, "A cat", img.Alt)
+	assert.Equal(t, "Our &lt;cat&gt;", img.Caption)
+
+	linked := doc.Children[1].(*lexical.Image)
+	assert.Equal(t, "/logo.png", linked.Src)
+	assert.Equal(t, "https://example.com", linked.Href)
+
+	assert.Equal(t, "Before ", doc.Children[2].(*lexical.Paragraph).Children[0].(*lexical.Text).Text)
+	assert.Equal(t, "/i.png", doc.Children[3].(*lexical.Image).Src)
+	assert.Equal(t, " after", doc.Children[4].(*lexical.Paragraph).Children[0].(*lexical.Text).Text)
+}
+
+func TestMarkdownToLexical_Blocks(t *testing.T) {
+	doc := MarkdownStringToLexical("> one\n>\n> two\n\n" +
+		"1. first\n2. second\n   - nested\n\n" +
+		"| a | b |\n|---|---|\n| 1 | 2 |\n\n" +
+		"## Custom {#custom-id}\n\n" +
+		"<div class=\"x\">raw</div>\n\n" +
+		"Inline <b>html</b>\n")
+	require.Len(t, doc.Children, 6)
+
+	quote := doc.Children[0].(*lexical.Quote)
+	require.Len(t, quote.Children, 4)
+	assert.IsType(t, &lexical.LineBreak{}, quote.Children[1])
+
+	list := doc.Children[1].(*lexical.List)
+	assert.Equal(t, lexical.ListNumber, list.ListType)
+	require.Len(t, list.Children, 3)
+	nested := list.Children[2].(*lexical.ListItem).Children[0].(*lexical.List)
+	assert.Equal(t, lexical.ListBullet, nested.ListType)
+	assert.Equal(t, "nested", nested.Children[0].(*lexical.ListItem).Children[0].(*lexical.Text).Text)
+
+	table := doc.Children[2].(*lexical.HTML)
+	assert.True(t, strings.HasPrefix(table.HTML, "<table>"))
+	assert.Contains(t, table.HTML, "<td>1</td>")
+
+	assert.Equal(t, `<h2 id="custom-id">Custom</h2>`, doc.Children[3].(*lexical.HTML).HTML)
+	assert.Equal(t, `<div class="x">raw</div>`, doc.Children[4].(*lexical.HTML).HTML)
+	assert.Equal(t, `<p>Inline <b>html</b></p>`, doc.Children[5].(*lexical.HTML).HTML)
+}
+
+func TestMarkdownToLexical_Fallbacks(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"code in list", "- item\n\n        code\n"},
+		{"list in quote", "> - item\n"},
+		{"image in list", "- ![x](/x.png)\n"},
+		{"definition list", "Term\n: Definition\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := MarkdownStringToLexical(tt.input)
+			require.Len(t, doc.Children, 1)
+			assert.IsType(t, &lexical.HTML{}, doc.Children[0])
+		})
+	}
+}
+
+func TestMarkdownToLexical_Empty(t *testing.T) {
+	assert.Empty(t, MarkdownStringToLexical("").Children)
+	assert.Empty(t, MarkdownStringToLexical("\n\n").Children)
+}
+
+func TestMarkdownToMobiledoc(t *testing.T) {
+	doc := MarkdownToMobiledoc([]byte("## Title\n\n" +
+		"Some *emphasis* and a [link](https://ghost.org).\n\n" +
+		"- one\n- two\n\n" +
+		"```go\nx := 1\n```\n"))
+	assert.Equal(t, "<h2>Title</h2>"+
+		`<p>Some <em>emphasis</em> and a <a href="https://ghost.org">link</a>.</p>`+
+		"<ul><li>one</li><li>two</li></ul>"+
+		`<pre><code class="language-go">x := 1</code></pre>`, doc.HTML())
+	assert.Empty(t, MarkdownStringToMobiledoc("").Sections)
+}
+
 // Fuzz tests
 
 func FuzzMarkdownToHTML(f *testing.F) {
@@ -335,6 +458,29 @@ func FuzzMarkdownToHTML(f *testing.F) {
 	})
 }
 
+func FuzzMarkdownToLexical(f *testing.F) {
+	for _, seed := range []string{
+		"# Heading {#id}",
+		"**bold** and *italic* `code`",
+		"[![image](/a.png)](http://example.com)",
+		"```go\ncode\n```",
+		"- list\n  1. nested",
+		"> quote\n>\n> more",
+		"| a | b |\n|---|---|\n| 1 | 2 |",
+		"Term\n: Definition",
+		"<div>html</div> <b>span</b>",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		data, err := json.Marshal(MarkdownStringToLexical(input))
+		require.NoError(t, err)
+		_, err = lexical.Parse(data)
+		require.NoError(t, err)
+	})
+}
+
 // Benchmarks
 
 func BenchmarkMarkdownToHTML_Simple(b *testing.B) {
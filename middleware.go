@@ -0,0 +1,128 @@
+package libecto
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Request describes an API call as seen by middleware.
+// Middleware may modify any field before passing the request on.
+type Request struct {
+	// Method is the HTTP method.
+	Method string
+	// Path is the request path relative to the API base URL, including any query string.
+	Path string
+	// Header holds extra headers sent with the request. The Authorization and
+	// Content-Type headers are always set by the client.
+	Header http.Header
+	// Body is the value that will be encoded as the request body: a value to be
+	// marshaled as JSON, a *FileUpload for image uploads, or nil.
+	Body interface{}
+}
+
+// singletonResources are endpoints that return a single object rather than a list.
+var singletonResources = map[string]bool{
+	"site":     true,
+	"settings": true,
+	"config":   true,
+}
+
+// Operation returns a stable name for the call in "resource.action" form,
+// such as "posts.list", "posts.get", "posts.update" or "images.upload".
+// It is intended for span names, metric labels and log fields.
+func (r *Request) Operation() string {
+	segments := r.segments()
+	if len(segments) == 0 {
+		return "unknown"
+	}
+	resource := segments[0]
+
+	var action string
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if len(segments) == 1 && !singletonResources[resource] {
+			action = "list"
+		} else {
+			action = "get"
+		}
+	case http.MethodPost:
+		if len(segments) == 2 {
+			action = segments[1]
+		} else {
+			action = "create"
+		}
+	case http.MethodPut:
+		action = "update"
+	case http.MethodDelete:
+		action = "delete"
+	default:
+		action = strings.ToLower(r.Method)
+	}
+	return resource + "." + action
+}
+
+// segments returns the non-empty path segments, ignoring any query string.
+func (r *Request) segments() []string {
+	path, _, _ := strings.Cut(r.Path, "?")
+	return strings.FieldsFunc(path, func(c rune) bool { return c == '/' })
+}
+
+// resource returns the resource type of the request, such as "posts" or "tags".
+func (r *Request) resource() string {
+	if segments := r.segments(); len(segments) > 0 {
+		return segments[0]
+	}
+	return ""
+}
+
+// Response is a fully read API response as seen by middleware.
+// Error responses are passed through as a Response; the client converts them
+// to a *ResponseError after the middleware chain returns.
+type Response struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+	// Header holds the response headers.
+	Header http.Header
+	// Body is the raw response body.
+	Body []byte
+}
+
+// Handler performs an API call and returns its response.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler to add behavior around every API call, such as
+// auditing, header injection or fault injection. A middleware may return a
+// response or error without calling next to short-circuit the call.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware to the client. Middleware runs in the order
+// given, with the first one outermost, and wraps every endpoint including
+// image uploads. Retries and rate limiting happen inside the chain, so
+// middleware sees each logical call once.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// build assembles the client's handler: the built-in layers around send,
+// wrapped by the user's middleware.
+func (c *Client) build() {
+	h := Handler(c.send)
+	if c.cache != nil {
+		h = c.cache.wrap(c.baseURL, h)
+	}
+	if c.dryRun != nil {
+		h = c.dryRun.wrap(h)
+	}
+	c.handler = c.chain(h)
+}
+
+// chain wraps h with the client's middleware, first middleware outermost.
+func (c *Client) chain(h Handler) Handler {
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
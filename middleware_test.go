@@ -0,0 +1,200 @@
+package libecto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithMiddleware_Order(t *testing.T) {
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				order = append(order, name+":before")
+				resp, err := next(ctx, req)
+				order = append(order, name+":after")
+				return resp, err
+			}
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "server")
+		w.Write([]byte(`{"site":{}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testAPIKey, WithMiddleware(trace("outer")), WithMiddleware(trace("inner")))
+	_, err := client.GetSite()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer:before", "inner:before", "server", "inner:after", "outer:after"}, order)
+}
+
+func TestClient_WithMiddleware_SeesRequestAndResponse(t *testing.T) {
+	var seen *Request
+	var seenResp *Response
+	audit := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			seen = req
+			resp, err := next(ctx, req)
+			seenResp = resp
+			return resp, err
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(ErrorResponse{Errors: []APIError{{Message: "bad", Type: ErrorTypeValidation}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testAPIKey, WithMiddleware(audit))
+	_, err := client.CreatePost(&Post{Title: "Audited"})
+	require.Error(t, err)
+	assert.True(t, IsValidation(err))
+
+	require.NotNil(t, seen)
+	assert.Equal(t, "POST", seen.Method)
+	assert.True(t, strings.HasPrefix(seen.Path, "/posts/"))
+	body, ok := seen.Body.(map[string][]Post)
+	require.True(t, ok)
+	assert.Equal(t, "Audited", body["posts"][0].Title)
+
+	require.NotNil(t, seenResp)
+	assert.Equal(t, http.StatusUnprocessableEntity, seenResp.StatusCode)
+	assert.Contains(t, string(seenResp.Body), "bad")
+}
+
+func TestClient_WithMiddleware_InjectsHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "publisher", r.Header.Get("X-Request-Source"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Ghost "))
+		w.Write([]byte(`{"tags":[]}`))
+	}))
+	defer server.Close()
+
+	inject := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("X-Request-Source", "publisher")
+			req.Header.Set("Authorization", "overridden")
+			return next(ctx, req)
+		}
+	}
+
+	client := NewClient(server.URL, testAPIKey, WithMiddleware(inject))
+	_, err := client.ListTags(0)
+	require.NoError(t, err)
+}
+
+func TestClient_WithMiddleware_ModifiesResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"site":{"title":"Original"}}`))
+	}))
+	defer server.Close()
+
+	rewrite := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := next(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = []byte(strings.Replace(string(resp.Body), "Original", "Rewritten", 1))
+			return resp, nil
+		}
+	}
+
+	client := NewClient(server.URL, testAPIKey, WithMiddleware(rewrite))
+	site, err := client.GetSite()
+	require.NoError(t, err)
+	assert.Equal(t, "Rewritten", site.Title)
+}
+
+func TestClient_WithMiddleware_FaultInjection(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	errInjected := errors.New("injected")
+	fail := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if req.Method == "DELETE" {
+				return nil, errInjected
+			}
+			return &Response{StatusCode: http.StatusServiceUnavailable, Body: []byte("maintenance")}, nil
+		}
+	}
+
+	client := NewClient(server.URL, testAPIKey, WithMiddleware(fail))
+	err := client.DeletePost("123")
+	assert.ErrorIs(t, err, errInjected)
+
+	_, err = client.GetSite()
+	var respErr *ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusServiceUnavailable, respErr.StatusCode)
+	assert.False(t, called)
+}
+
+func TestClient_WithMiddleware_Upload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, "renamed.jpg", header.Filename)
+		assert.Equal(t, "image data", string(data))
+		w.Write([]byte(`{"images":[{"url":"https://example.com/renamed.jpg"}]}`))
+	}))
+	defer server.Close()
+
+	rename := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			upload, ok := req.Body.(*FileUpload)
+			require.True(t, ok)
+			assert.Equal(t, "/images/upload/", req.Path)
+			upload.Filename = "renamed.jpg"
+			return next(ctx, req)
+		}
+	}
+
+	client := NewClient(server.URL, testAPIKey, WithMiddleware(rename))
+	resp, err := client.UploadImageReader(strings.NewReader("image data"), "original.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/renamed.jpg", resp.Images[0].URL)
+}
+
+func TestRequest_Operation(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{"GET", "/posts/?formats=html&limit=5", "posts.list"},
+		{"GET", "/posts/abc123/?formats=html", "posts.get"},
+		{"GET", "/posts/slug/my-post/?formats=html", "posts.get"},
+		{"POST", "/posts/?source=html", "posts.create"},
+		{"PUT", "/posts/abc123/", "posts.update"},
+		{"DELETE", "/posts/abc123/", "posts.delete"},
+		{"POST", "/images/upload/", "images.upload"},
+		{"GET", "/site/", "site.get"},
+		{"GET", "/settings/", "settings.get"},
+		{"PATCH", "/tags/1/", "tags.patch"},
+		{"GET", "/", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := &Request{Method: tt.method, Path: tt.path}
+			assert.Equal(t, tt.want, req.Operation())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package libecto
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/visionik/libecto/mobiledoc"
+)
+
+// SetMobiledoc sets the post content to doc, for Ghost 4 and earlier. HTML
+// and Lexical are cleared so Ghost stores the document as is.
+func (p *Post) SetMobiledoc(doc *mobiledoc.Document) error {
+	s, err := encodeMobiledoc(doc)
+	if err != nil {
+		return err
+	}
+	p.Mobiledoc, p.HTML, p.Lexical = s, "", ""
+	return nil
+}
+
+// MobiledocDocument parses the post's Mobiledoc content. It returns an empty
+// document if the post has none. GetPost returns Mobiledoc content by default;
+// list calls return it when FormatMobiledoc is requested.
+func (p *Post) MobiledocDocument() (*mobiledoc.Document, error) {
+	return decodeMobiledoc(p.Mobiledoc)
+}
+
+// SetMobiledoc sets the page content to doc, for Ghost 4 and earlier. HTML
+// and Lexical are cleared so Ghost stores the document as is.
+func (p *Page) SetMobiledoc(doc *mobiledoc.Document) error {
+	s, err := encodeMobiledoc(doc)
+	if err != nil {
+		return err
+	}
+	p.Mobiledoc, p.HTML, p.Lexical = s, "", ""
+	return nil
+}
+
+// MobiledocDocument parses the page's Mobiledoc content. It returns an empty
+// document if the page has none.
+func (p *Page) MobiledocDocument() (*mobiledoc.Document, error) {
+	return decodeMobiledoc(p.Mobiledoc)
+}
+
+func encodeMobiledoc(doc *mobiledoc.Document) (string, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode mobiledoc: %w", err)
+	}
+	return string(data), nil
+}
+
+func decodeMobiledoc(s string) (*mobiledoc.Document, error) {
+	if s == "" {
+		return mobiledoc.New(), nil
+	}
+	doc, err := mobiledoc.ParseString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode mobiledoc: %w", err)
+	}
+	return doc, nil
+}
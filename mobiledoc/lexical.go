@@ -0,0 +1,337 @@
+package mobiledoc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/visionik/libecto/lexical"
+)
+
+// markupFormats maps inline markups to Lexical text formats.
+var markupFormats = map[string]lexical.TextFormat{
+	"b": lexical.FormatBold, "strong": lexical.FormatBold,
+	"i": lexical.FormatItalic, "em": lexical.FormatItalic,
+	"s": lexical.FormatStrikethrough, "del": lexical.FormatStrikethrough, "strike": lexical.FormatStrikethrough,
+	"u":    lexical.FormatUnderline,
+	"code": lexical.FormatCode,
+	"sub":  lexical.FormatSubscript,
+	"sup":  lexical.FormatSuperscript,
+}
+
+// formatMarkups lists the markups FromLexical writes for each text format, in
+// the order they are opened.
+var formatMarkups = []struct {
+	format lexical.TextFormat
+	tag    string
+}{
+	{lexical.FormatBold, "strong"},
+	{lexical.FormatItalic, "em"},
+	{lexical.FormatStrikethrough, "s"},
+	{lexical.FormatUnderline, "u"},
+	{lexical.FormatCode, "code"},
+	{lexical.FormatSubscript, "sub"},
+	{lexical.FormatSuperscript, "sup"},
+}
+
+// cardTypes maps Mobiledoc card names to Lexical node types where they
+// differ. Other cards share their name and payload fields with Lexical.
+var cardTypes = map[string]string{
+	"hr":   "horizontalrule",
+	"code": "codeblock",
+}
+
+// ToLexical converts the document to Lexical, for moving content written on
+// Ghost 4 and earlier to the Ghost 5 editor. Markups become text formats and
+// links, soft returns become line breaks, "aside" sections become quotes and
+// image sections become image cards. Cards become the Lexical card of the
+// same kind with the same payload; those the lexical package does not model,
+// such as buttons or toggles, are kept as *lexical.Unknown nodes.
+func (d *Document) ToLexical() (*lexical.Document, error) {
+	doc := lexical.New()
+	for _, s := range d.Sections {
+		switch s := s.(type) {
+		case *MarkupSection:
+			inlines := d.lexicalInlines(s.Markers)
+			switch s.Tag {
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				h := lexical.NewHeading(1, inlines...)
+				h.Tag = s.Tag
+				doc.Append(h)
+			case "blockquote", "aside":
+				doc.Append(lexical.NewQuote(inlines...))
+			default:
+				doc.Append(lexical.NewParagraph(inlines...))
+			}
+		case *ListSection:
+			items := make([]*lexical.ListItem, len(s.Items))
+			for i, item := range s.Items {
+				items[i] = lexical.NewListItem(d.lexicalInlines(item)...)
+			}
+			if s.Tag == "ol" {
+				doc.Append(lexical.NewNumberedList(items...))
+			} else {
+				doc.Append(lexical.NewBulletList(items...))
+			}
+		case *ImageSection:
+			doc.Append(lexical.NewImage(s.Src, "", ""))
+		case *CardSection:
+			if s.Card < 0 || s.Card >= len(d.Cards) {
+				continue
+			}
+			node, err := lexicalCard(d.Cards[s.Card])
+			if err != nil {
+				return nil, err
+			}
+			doc.Append(node)
+		}
+	}
+	return doc, nil
+}
+
+// lexicalInlines converts markers to Lexical text, line breaks and links.
+func (d *Document) lexicalInlines(markers []Marker) []lexical.Node {
+	type open struct {
+		format lexical.TextFormat
+		link   *lexical.Link
+	}
+	var stack []open
+	var out []lexical.Node
+	// current returns the innermost open link, or nil.
+	current := func() *lexical.Link {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].link != nil {
+				return stack[i].link
+			}
+		}
+		return nil
+	}
+	add := func(n lexical.Node) {
+		if link := current(); link != nil {
+			link.Append(n)
+		} else {
+			out = append(out, n)
+		}
+	}
+
+	for _, m := range markers {
+		for _, i := range m.Open {
+			var o open
+			if i >= 0 && i < len(d.Markups) {
+				markup := d.Markups[i]
+				o.format = markupFormats[markup.Tag]
+				// Lexical links cannot nest; inner links keep only their text.
+				if markup.Tag == "a" && current() == nil {
+					o.link = lexical.NewLink(markup.Attr("href"))
+					o.link.Rel, o.link.Target, o.link.Title = markup.Attr("rel"), markup.Attr("target"), markup.Attr("title")
+					out = append(out, o.link)
+				}
+			}
+			stack = append(stack, o)
+		}
+
+		var format lexical.TextFormat
+		for _, o := range stack {
+			format |= o.format
+		}
+		switch {
+		case !m.IsAtom:
+			if m.Text != "" {
+				add(lexical.NewFormattedText(m.Text, format))
+			}
+		case m.Atom < 0 || m.Atom >= len(d.Atoms):
+		case d.Atoms[m.Atom].Name == "soft-return":
+			add(&lexical.LineBreak{})
+		case d.Atoms[m.Atom].Text != "":
+			add(lexical.NewFormattedText(d.Atoms[m.Atom].Text, format))
+		}
+
+		for i := 0; i < m.Close && len(stack) > 0; i++ {
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return out
+}
+
+// lexicalCard converts a card to the Lexical node of the same kind.
+func lexicalCard(c Card) (lexical.Node, error) {
+	fields := make(map[string]interface{}, len(c.Payload)+2)
+	for k, v := range c.Payload {
+		fields[k] = v
+	}
+	typ := c.Name
+	if t, ok := cardTypes[typ]; ok {
+		typ = t
+	}
+	if typ == "embed" {
+		fields["embedType"] = fields["type"]
+		delete(fields, "type")
+	}
+	fields["type"] = typ
+	if _, ok := fields["version"]; !ok {
+		fields["version"] = 1
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("mobiledoc: encoding %s card: %w", c.Name, err)
+	}
+	node, err := lexical.DecodeNode(data)
+	if err != nil {
+		return nil, fmt.Errorf("mobiledoc: converting %s card: %w", c.Name, err)
+	}
+	return node, nil
+}
+
+// FromLexical converts a Lexical document to Mobiledoc, for writing content
+// to Ghost 4 and earlier. Text formats become markups, line breaks become
+// soft-return atoms and nested lists are flattened, as Mobiledoc lists cannot
+// nest. Cards are written with the name and payload Ghost's Mobiledoc editor
+// uses for the same kind of card.
+func FromLexical(doc *lexical.Document) (*Document, error) {
+	d := New()
+	var inline []lexical.Node
+	flush := func() {
+		if len(inline) > 0 {
+			d.Append(NewMarkupSection("p", d.markers(inline)...))
+			inline = nil
+		}
+	}
+	for _, n := range doc.Children {
+		switch n := n.(type) {
+		case *lexical.Paragraph:
+			flush()
+			d.Append(NewMarkupSection("p", d.markers(n.Children)...))
+		case *lexical.Heading:
+			flush()
+			tag := n.Tag
+			if n.Level() == 0 {
+				tag = "h2"
+			}
+			d.Append(NewMarkupSection(tag, d.markers(n.Children)...))
+		case *lexical.Quote:
+			flush()
+			d.Append(NewMarkupSection("blockquote", d.markers(n.Children)...))
+		case *lexical.List:
+			flush()
+			tag := "ul"
+			if n.ListType == lexical.ListNumber {
+				tag = "ol"
+			}
+			d.Append(NewListSection(tag, d.listItems(n)...))
+		case *lexical.Text, *lexical.Link, *lexical.LineBreak, *lexical.Tab:
+			// Inline nodes at the top level are gathered into a paragraph.
+			inline = append(inline, n)
+		default:
+			flush()
+			if err := d.appendCard(n); err != nil {
+				return nil, err
+			}
+		}
+	}
+	flush()
+	return d, nil
+}
+
+// listItems returns the items of list, with the items of nested lists
+// following the item that holds them.
+func (d *Document) listItems(list *lexical.List) [][]Marker {
+	var items [][]Marker
+	for _, child := range list.Children {
+		item, ok := child.(*lexical.ListItem)
+		if !ok {
+			continue
+		}
+		var inline []lexical.Node
+		var nested []*lexical.List
+		for _, n := range item.Children {
+			if l, ok := n.(*lexical.List); ok {
+				nested = append(nested, l)
+			} else {
+				inline = append(inline, n)
+			}
+		}
+		if len(inline) > 0 || len(nested) == 0 {
+			items = append(items, d.markers(inline))
+		}
+		for _, l := range nested {
+			items = append(items, d.listItems(l)...)
+		}
+	}
+	return items
+}
+
+// markers converts Lexical inline nodes to markers.
+func (d *Document) markers(nodes []lexical.Node) []Marker {
+	var out []Marker
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *lexical.Text:
+			var open []int
+			for _, fm := range formatMarkups {
+				if n.Format.Has(fm.format) {
+					open = append(open, d.AddMarkup(fm.tag))
+				}
+			}
+			out = append(out, TextMarker(n.Text, open, len(open)))
+		case *lexical.Tab:
+			out = append(out, TextMarker("\t", nil, 0))
+		case *lexical.LineBreak:
+			out = append(out, AtomMarker(d.softReturn(), nil, 0))
+		case *lexical.Link:
+			attrs := []string{"href", n.URL}
+			for _, a := range [][2]string{{"rel", n.Rel}, {"target", n.Target}, {"title", n.Title}} {
+				if a[1] != "" {
+					attrs = append(attrs, a[0], a[1])
+				}
+			}
+			a := d.AddMarkup("a", attrs...)
+			inner := d.markers(n.Children)
+			if len(inner) == 0 {
+				inner = []Marker{TextMarker("", nil, 0)}
+			}
+			// The link opens before the first marker's own markups and closes
+			// after the last marker's.
+			inner[0].Open = append([]int{a}, inner[0].Open...)
+			inner[len(inner)-1].Close++
+			out = append(out, inner...)
+		}
+	}
+	return out
+}
+
+// softReturn returns the index of the document's soft-return atom, adding
+// one if needed.
+func (d *Document) softReturn() int {
+	for i, a := range d.Atoms {
+		if a.Name == "soft-return" {
+			return i
+		}
+	}
+	return d.AddAtom("soft-return", "", nil)
+}
+
+// appendCard adds a card section for a Lexical card node.
+func (d *Document) appendCard(n lexical.Node) error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("mobiledoc: encoding %s node: %w", n.Type(), err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("mobiledoc: encoding %s node: %w", n.Type(), err)
+	}
+	name := n.Type()
+	for card, typ := range cardTypes {
+		if typ == name {
+			name = card
+		}
+	}
+	delete(fields, "type")
+	delete(fields, "version")
+	if name == "embed" {
+		fields["type"] = fields["embedType"]
+		delete(fields, "embedType")
+	}
+	d.Append(d.AddCard(name, fields))
+	return nil
+}
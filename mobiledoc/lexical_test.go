@@ -0,0 +1,140 @@
+package mobiledoc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/visionik/libecto/lexical"
+)
+
+func TestDocument_ToLexical(t *testing.T) {
+	doc, err := ParseString(ghostDoc)
+	require.NoError(t, err)
+	lex, err := doc.ToLexical()
+	require.NoError(t, err)
+	require.Len(t, lex.Children, 11)
+
+	h := lex.Children[0].(*lexical.Heading)
+	assert.Equal(t, "h2", h.Tag)
+	assert.Equal(t, []lexical.Node{lexical.NewText("Title")}, h.Children)
+
+	p := lex.Children[1].(*lexical.Paragraph)
+	link := lexical.NewLink("https://ghost.org",
+		lexical.NewFormattedText("linked ", lexical.FormatItalic),
+		lexical.NewText("text"),
+	)
+	link.Rel = "noopener"
+	assert.Equal(t, []lexical.Node{
+		lexical.NewText("Hello "),
+		lexical.NewFormattedText("bold", lexical.FormatBold),
+		lexical.NewText(" and "),
+		link,
+		&lexical.LineBreak{},
+		lexical.NewText("<x>"),
+	}, p.Children)
+
+	list := lex.Children[2].(*lexical.List)
+	assert.Equal(t, lexical.ListBullet, list.ListType)
+	assert.Len(t, list.Children, 2)
+	assert.IsType(t, &lexical.Quote{}, lex.Children[3])
+
+	img := lex.Children[4].(*lexical.Image)
+	assert.Equal(t, "https://example.com/a.png", img.Src)
+	assert.Equal(t, lexical.CardWidthWide, img.CardWidth)
+	code := lex.Children[5].(*lexical.CodeBlock)
+	assert.Equal(t, "x := 1", code.Code)
+	assert.Equal(t, "go", code.Language)
+	assert.IsType(t, &lexical.HorizontalRule{}, lex.Children[6])
+	assert.Equal(t, "**md**", lex.Children[7].(*lexical.Markdown).Markdown)
+	embed := lex.Children[8].(*lexical.Embed)
+	assert.Equal(t, "video", embed.EmbedType)
+	assert.Equal(t, "https://youtu.be/x", embed.URL)
+	unknown := lex.Children[9].(*lexical.Unknown)
+	assert.Equal(t, "button", unknown.Type())
+	assert.Equal(t, "https://example.com/old.png", lex.Children[10].(*lexical.Image).Src)
+}
+
+func TestDocument_ToLexicalNestedLinks(t *testing.T) {
+	doc := New()
+	outer := doc.AddMarkup("a", "href", "https://a")
+	inner := doc.AddMarkup("a", "href", "https://b")
+	doc.Append(NewMarkupSection("p",
+		TextMarker("one ", []int{outer}, 0),
+		TextMarker("two", []int{inner}, 2),
+		TextMarker(" three", nil, 0),
+	))
+	lex, err := doc.ToLexical()
+	require.NoError(t, err)
+	assert.Equal(t, []lexical.Node{
+		lexical.NewLink("https://a", lexical.NewText("one "), lexical.NewText("two")),
+		lexical.NewText(" three"),
+	}, lex.Children[0].(*lexical.Paragraph).Children)
+}
+
+func TestFromLexical(t *testing.T) {
+	link := lexical.NewLink("https://ghost.org", lexical.NewFormattedText("Ghost", lexical.FormatBold))
+	link.Target = "_blank"
+	embed := lexical.NewEmbed("https://youtu.be/x", "<iframe></iframe>")
+	embed.EmbedType = "video"
+	nested := lexical.NewBulletList(lexical.NewListItem(lexical.NewText("inner")))
+	doc := lexical.New(
+		lexical.NewHeading(3, lexical.NewText("Title")),
+		lexical.NewParagraph(lexical.NewText("Try "), link, &lexical.LineBreak{}, lexical.NewText("now")),
+		lexical.NewNumberedList(
+			lexical.NewListItem(lexical.NewText("one"), nested),
+			lexical.NewListItem(lexical.NewText("two")),
+		),
+		lexical.NewQuote(lexical.NewFormattedText("quoted", lexical.FormatItalic|lexical.FormatCode)),
+		lexical.NewCodeBlock("x := 1", "go"),
+		lexical.NewHorizontalRule(),
+		embed,
+	)
+
+	md, err := FromLexical(doc)
+	require.NoError(t, err)
+	data, err := json.Marshal(md)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"0.3.1",
+		"atoms":[["soft-return","",{}]],
+		"cards":[
+			["code",{"code":"x := 1","language":"go","caption":""}],
+			["hr",{}],
+			["embed",{"url":"https://youtu.be/x","html":"<iframe></iframe>","type":"video","metadata":{},"caption":""}]
+		],
+		"markups":[["a",["href","https://ghost.org","target","_blank"]],["strong"],["em"],["code"]],
+		"sections":[
+			[1,"h3",[[0,[],0,"Title"]]],
+			[1,"p",[[0,[],0,"Try "],[0,[0,1],2,"Ghost"],[1,[],0,0],[0,[],0,"now"]]],
+			[3,"ol",[[[0,[],0,"one"]],[[0,[],0,"inner"]],[[0,[],0,"two"]]]],
+			[1,"blockquote",[[0,[2,3],2,"quoted"]]],
+			[10,0],[10,1],[10,2]
+		]}`, string(data))
+}
+
+func TestFromLexical_RoundTrip(t *testing.T) {
+	doc, err := ParseString(ghostDoc)
+	require.NoError(t, err)
+	lex, err := doc.ToLexical()
+	require.NoError(t, err)
+	back, err := FromLexical(lex)
+	require.NoError(t, err)
+
+	// Asides become blockquotes and image sections become image cards;
+	// everything else renders the same.
+	want := doc.HTML()
+	want = strings.ReplaceAll(want, `<blockquote class="kg-blockquote-alt">alt</blockquote>`, `<blockquote>alt</blockquote>`)
+	want = strings.ReplaceAll(want, `<img src="https://example.com/old.png">`,
+		`<figure class="kg-card kg-image-card"><img src="https://example.com/old.png" class="kg-image" alt="" loading="lazy"></figure>`)
+	assert.Equal(t, want, back.HTML())
+	assert.Equal(t, "button", back.Cards[len(back.Cards)-2].Name)
+}
+
+func TestFromLexical_TopLevelInlines(t *testing.T) {
+	md, err := FromLexical(lexical.New(lexical.NewText("loose"), lexical.NewHorizontalRule()))
+	require.NoError(t, err)
+	assert.Equal(t, "<p>loose</p><hr>", md.HTML())
+}
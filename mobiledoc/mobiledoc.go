@@ -0,0 +1,493 @@
+// Package mobiledoc models Mobiledoc documents, the content format of Ghost
+// 1 to 4 stored in Post.Mobiledoc.
+//
+// A document is a list of sections. Markup sections (paragraphs, headings and
+// quotes) and list sections hold markers: runs of text or atoms that open and
+// close markups such as links and bold. Card sections place cards, the
+// editor's self-contained blocks, whose payloads are kept as decoded JSON:
+//
+//	doc := mobiledoc.New()
+//	strong := doc.AddMarkup("strong")
+//	doc.Append(
+//		mobiledoc.NewMarkupSection("h2", mobiledoc.TextMarker("Notes", nil, 0)),
+//		mobiledoc.NewMarkupSection("p",
+//			mobiledoc.TextMarker("Read ", nil, 0),
+//			mobiledoc.TextMarker("this", []int{strong}, 1),
+//		),
+//		doc.AddCard("code", map[string]interface{}{"code": "go test ./...", "language": "bash"}),
+//	)
+//	html := doc.HTML()
+//
+// Documents convert to and from Lexical with ToLexical and FromLexical.
+package mobiledoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Version is the Mobiledoc version written by New.
+const Version = "0.3.1"
+
+// Section type identifiers.
+const (
+	MarkupSectionType = 1
+	ImageSectionType  = 2
+	ListSectionType   = 3
+	CardSectionType   = 10
+)
+
+// Document is a Mobiledoc document.
+type Document struct {
+	Version  string
+	Markups  []Markup
+	Atoms    []Atom
+	Cards    []Card
+	Sections []Section
+}
+
+// Markup is an inline markup such as "strong" or "a", with its attributes
+// as alternating names and values.
+type Markup struct {
+	Tag        string
+	Attributes []string
+}
+
+// Attr returns the value of the named attribute, or "" if it is not set.
+func (m Markup) Attr(name string) string {
+	for i := 0; i+1 < len(m.Attributes); i += 2 {
+		if m.Attributes[i] == name {
+			return m.Attributes[i+1]
+		}
+	}
+	return ""
+}
+
+// Atom is an inline element rendered by the editor, such as Ghost's
+// "soft-return" line break.
+type Atom struct {
+	Name    string
+	Text    string
+	Payload map[string]interface{}
+}
+
+// Card is a block-level element with a name, such as "image" or "code", and
+// a JSON payload.
+type Card struct {
+	Name    string
+	Payload map[string]interface{}
+}
+
+// Section is a top-level block of a document.
+type Section interface {
+	// SectionType returns the section's type identifier, such as MarkupSectionType.
+	SectionType() int
+}
+
+// MarkupSection is a paragraph, heading or quote. Tag is "p", "h1" to "h6",
+// "blockquote" or "aside".
+type MarkupSection struct {
+	Tag     string
+	Markers []Marker
+	// Attributes are alternating names and values (Mobiledoc 0.3.2).
+	Attributes []string
+}
+
+// SectionType returns MarkupSectionType.
+func (*MarkupSection) SectionType() int { return MarkupSectionType }
+
+// ImageSection is an image without a card, as written by early editors.
+type ImageSection struct {
+	Src string
+}
+
+// SectionType returns ImageSectionType.
+func (*ImageSection) SectionType() int { return ImageSectionType }
+
+// ListSection is a bulleted ("ul") or numbered ("ol") list; each item is a
+// list of markers.
+type ListSection struct {
+	Tag   string
+	Items [][]Marker
+	// Attributes are alternating names and values (Mobiledoc 0.3.2).
+	Attributes []string
+}
+
+// SectionType returns ListSectionType.
+func (*ListSection) SectionType() int { return ListSectionType }
+
+// CardSection places Document.Cards[Card].
+type CardSection struct {
+	Card int
+}
+
+// SectionType returns CardSectionType.
+func (*CardSection) SectionType() int { return CardSectionType }
+
+// Marker is a run of text or an atom. It opens the markups indexed by Open,
+// in order, and afterwards closes the Close most recently opened markups.
+type Marker struct {
+	Text   string
+	IsAtom bool
+	// Atom indexes Document.Atoms when IsAtom is set.
+	Atom  int
+	Open  []int
+	Close int
+}
+
+// TextMarker returns a text marker.
+func TextMarker(text string, open []int, close int) Marker {
+	return Marker{Text: text, Open: open, Close: close}
+}
+
+// AtomMarker returns a marker for Document.Atoms[atom].
+func AtomMarker(atom int, open []int, close int) Marker {
+	return Marker{IsAtom: true, Atom: atom, Open: open, Close: close}
+}
+
+// NewMarkupSection returns a markup section with the given tag.
+func NewMarkupSection(tag string, markers ...Marker) *MarkupSection {
+	return &MarkupSection{Tag: tag, Markers: markers}
+}
+
+// NewListSection returns a list section with the given tag, "ul" or "ol".
+func NewListSection(tag string, items ...[]Marker) *ListSection {
+	return &ListSection{Tag: tag, Items: items}
+}
+
+// New returns an empty document of the current Version.
+func New() *Document {
+	return &Document{Version: Version}
+}
+
+// Append adds sections to the end of the document.
+func (d *Document) Append(sections ...Section) {
+	d.Sections = append(d.Sections, sections...)
+}
+
+// AddMarkup returns the index of the markup with the given tag and
+// attributes, adding it if the document does not have it yet.
+func (d *Document) AddMarkup(tag string, attrs ...string) int {
+	for i, m := range d.Markups {
+		if m.Tag == tag && equalStrings(m.Attributes, attrs) {
+			return i
+		}
+	}
+	d.Markups = append(d.Markups, Markup{Tag: tag, Attributes: attrs})
+	return len(d.Markups) - 1
+}
+
+// AddAtom adds an atom and returns its index.
+func (d *Document) AddAtom(name, text string, payload map[string]interface{}) int {
+	d.Atoms = append(d.Atoms, Atom{Name: name, Text: text, Payload: payload})
+	return len(d.Atoms) - 1
+}
+
+// AddCard adds a card and returns a section placing it, to be appended.
+func (d *Document) AddCard(name string, payload map[string]interface{}) *CardSection {
+	d.Cards = append(d.Cards, Card{Name: name, Payload: payload})
+	return &CardSection{Card: len(d.Cards) - 1}
+}
+
+// Parse decodes a serialized Mobiledoc document, such as the value of
+// Post.Mobiledoc.
+func Parse(data []byte) (*Document, error) {
+	var d Document
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ParseString is like Parse but takes a string.
+func ParseString(s string) (*Document, error) {
+	return Parse([]byte(s))
+}
+
+type documentJSON struct {
+	Version  string            `json:"version"`
+	Atoms    []json.RawMessage `json:"atoms"`
+	Cards    []json.RawMessage `json:"cards"`
+	Markups  []json.RawMessage `json:"markups"`
+	Sections []json.RawMessage `json:"sections"`
+}
+
+// MarshalJSON encodes the document in Mobiledoc's array-based format.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	version := d.Version
+	if version == "" {
+		version = Version
+	}
+	out := documentJSON{
+		Version:  version,
+		Atoms:    []json.RawMessage{},
+		Cards:    []json.RawMessage{},
+		Markups:  []json.RawMessage{},
+		Sections: []json.RawMessage{},
+	}
+	add := func(list *[]json.RawMessage, v interface{}) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, data)
+		return nil
+	}
+	for _, m := range d.Markups {
+		v := []interface{}{m.Tag}
+		if len(m.Attributes) > 0 {
+			v = append(v, m.Attributes)
+		}
+		if err := add(&out.Markups, v); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range d.Atoms {
+		if err := add(&out.Atoms, []interface{}{a.Name, a.Text, payload(a.Payload)}); err != nil {
+			return nil, fmt.Errorf("mobiledoc: encoding atom %q: %w", a.Name, err)
+		}
+	}
+	for _, c := range d.Cards {
+		if err := add(&out.Cards, []interface{}{c.Name, payload(c.Payload)}); err != nil {
+			return nil, fmt.Errorf("mobiledoc: encoding card %q: %w", c.Name, err)
+		}
+	}
+	for _, s := range d.Sections {
+		if err := add(&out.Sections, encodeSection(s)); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(out)
+}
+
+func encodeSection(s Section) []interface{} {
+	switch s := s.(type) {
+	case *MarkupSection:
+		v := []interface{}{MarkupSectionType, s.Tag, encodeMarkers(s.Markers)}
+		if len(s.Attributes) > 0 {
+			v = append(v, s.Attributes)
+		}
+		return v
+	case *ImageSection:
+		return []interface{}{ImageSectionType, s.Src}
+	case *ListSection:
+		items := make([][][]interface{}, len(s.Items))
+		for i, item := range s.Items {
+			items[i] = encodeMarkers(item)
+		}
+		v := []interface{}{ListSectionType, s.Tag, items}
+		if len(s.Attributes) > 0 {
+			v = append(v, s.Attributes)
+		}
+		return v
+	case *CardSection:
+		return []interface{}{CardSectionType, s.Card}
+	}
+	return []interface{}{s.SectionType()}
+}
+
+func encodeMarkers(markers []Marker) [][]interface{} {
+	out := make([][]interface{}, len(markers))
+	for i, m := range markers {
+		open := m.Open
+		if open == nil {
+			open = []int{}
+		}
+		if m.IsAtom {
+			out[i] = []interface{}{1, open, m.Close, m.Atom}
+		} else {
+			out[i] = []interface{}{0, open, m.Close, m.Text}
+		}
+	}
+	return out
+}
+
+// UnmarshalJSON decodes a Mobiledoc 0.3 document, checking that markers and
+// sections refer to existing markups, atoms and cards.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	var in documentJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(in.Version, "0.3") {
+		return fmt.Errorf("mobiledoc: unsupported version %q", in.Version)
+	}
+	doc := Document{Version: in.Version}
+
+	for _, raw := range in.Markups {
+		var v []json.RawMessage
+		if err := json.Unmarshal(raw, &v); err != nil || len(v) == 0 {
+			return fmt.Errorf("mobiledoc: invalid markup %s", raw)
+		}
+		var m Markup
+		if err := json.Unmarshal(v[0], &m.Tag); err != nil {
+			return fmt.Errorf("mobiledoc: invalid markup %s", raw)
+		}
+		if len(v) > 1 {
+			if err := json.Unmarshal(v[1], &m.Attributes); err != nil {
+				return fmt.Errorf("mobiledoc: invalid markup attributes %s", raw)
+			}
+		}
+		doc.Markups = append(doc.Markups, m)
+	}
+	for _, raw := range in.Atoms {
+		var a Atom
+		if err := unmarshalTuple(raw, &a.Name, &a.Text, &a.Payload); err != nil {
+			return fmt.Errorf("mobiledoc: invalid atom %s", raw)
+		}
+		doc.Atoms = append(doc.Atoms, a)
+	}
+	for _, raw := range in.Cards {
+		var c Card
+		if err := unmarshalTuple(raw, &c.Name, &c.Payload); err != nil {
+			return fmt.Errorf("mobiledoc: invalid card %s", raw)
+		}
+		doc.Cards = append(doc.Cards, c)
+	}
+	for _, raw := range in.Sections {
+		s, err := doc.decodeSection(raw)
+		if err != nil {
+			return err
+		}
+		doc.Sections = append(doc.Sections, s)
+	}
+	*d = doc
+	return nil
+}
+
+func (d *Document) decodeSection(raw json.RawMessage) (Section, error) {
+	var v []json.RawMessage
+	var typ int
+	if err := json.Unmarshal(raw, &v); err != nil || len(v) < 2 || json.Unmarshal(v[0], &typ) != nil {
+		return nil, fmt.Errorf("mobiledoc: invalid section %s", raw)
+	}
+	invalid := fmt.Errorf("mobiledoc: invalid section %s", raw)
+
+	switch typ {
+	case MarkupSectionType, ListSectionType:
+		if len(v) < 3 {
+			return nil, invalid
+		}
+		var tag string
+		var attrs []string
+		if json.Unmarshal(v[1], &tag) != nil {
+			return nil, invalid
+		}
+		if len(v) > 3 && json.Unmarshal(v[3], &attrs) != nil {
+			return nil, invalid
+		}
+		if typ == MarkupSectionType {
+			markers, err := d.decodeMarkers(v[2])
+			if err != nil {
+				return nil, err
+			}
+			return &MarkupSection{Tag: tag, Markers: markers, Attributes: attrs}, nil
+		}
+		var rawItems []json.RawMessage
+		if json.Unmarshal(v[2], &rawItems) != nil {
+			return nil, invalid
+		}
+		list := &ListSection{Tag: tag, Attributes: attrs, Items: [][]Marker{}}
+		for _, rawItem := range rawItems {
+			markers, err := d.decodeMarkers(rawItem)
+			if err != nil {
+				return nil, err
+			}
+			list.Items = append(list.Items, markers)
+		}
+		return list, nil
+	case ImageSectionType:
+		var s ImageSection
+		if json.Unmarshal(v[1], &s.Src) != nil {
+			return nil, invalid
+		}
+		return &s, nil
+	case CardSectionType:
+		var s CardSection
+		if json.Unmarshal(v[1], &s.Card) != nil {
+			return nil, invalid
+		}
+		if s.Card < 0 || s.Card >= len(d.Cards) {
+			return nil, fmt.Errorf("mobiledoc: section refers to missing card %d", s.Card)
+		}
+		return &s, nil
+	}
+	return nil, fmt.Errorf("mobiledoc: unknown section type %d", typ)
+}
+
+func (d *Document) decodeMarkers(raw json.RawMessage) ([]Marker, error) {
+	var rawMarkers []json.RawMessage
+	if err := json.Unmarshal(raw, &rawMarkers); err != nil {
+		return nil, fmt.Errorf("mobiledoc: invalid markers %s", raw)
+	}
+	markers := make([]Marker, 0, len(rawMarkers))
+	for _, rm := range rawMarkers {
+		var typ int
+		var m Marker
+		var value json.RawMessage
+		if err := unmarshalTuple(rm, &typ, &m.Open, &m.Close, &value); err != nil {
+			return nil, fmt.Errorf("mobiledoc: invalid marker %s", rm)
+		}
+		switch typ {
+		case 0:
+			if json.Unmarshal(value, &m.Text) != nil {
+				return nil, fmt.Errorf("mobiledoc: invalid marker %s", rm)
+			}
+		case 1:
+			m.IsAtom = true
+			if json.Unmarshal(value, &m.Atom) != nil {
+				return nil, fmt.Errorf("mobiledoc: invalid marker %s", rm)
+			}
+			if m.Atom < 0 || m.Atom >= len(d.Atoms) {
+				return nil, fmt.Errorf("mobiledoc: marker refers to missing atom %d", m.Atom)
+			}
+		default:
+			return nil, fmt.Errorf("mobiledoc: unknown marker type %d", typ)
+		}
+		for _, i := range m.Open {
+			if i < 0 || i >= len(d.Markups) {
+				return nil, fmt.Errorf("mobiledoc: marker refers to missing markup %d", i)
+			}
+		}
+		markers = append(markers, m)
+	}
+	return markers, nil
+}
+
+// unmarshalTuple decodes a JSON array into dst, element by element.
+func unmarshalTuple(raw json.RawMessage, dst ...interface{}) error {
+	var v []json.RawMessage
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	if len(v) < len(dst) {
+		return fmt.Errorf("expected %d elements, got %d", len(dst), len(v))
+	}
+	for i, d := range dst {
+		if err := json.Unmarshal(v[i], d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// payload returns p, or an empty map so it never encodes as null.
+func payload(p map[string]interface{}) map[string]interface{} {
+	if p == nil {
+		return map[string]interface{}{}
+	}
+	return p
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package mobiledoc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ghostDoc is a Mobiledoc document as saved by the Ghost 4 editor.
+const ghostDoc = `{"version":"0.3.1",
+	"atoms":[["soft-return","",{}]],
+	"cards":[
+		["image",{"src":"https://example.com/a.png","alt":"A","caption":"Cap <b>1</b>","cardWidth":"wide"}],
+		["code",{"code":"x := 1","language":"go"}],
+		["hr",{}],
+		["markdown",{"markdown":"**md**"}],
+		["embed",{"url":"https://youtu.be/x","html":"<iframe></iframe>","type":"video","metadata":{}}],
+		["button",{"buttonText":"Go","buttonUrl":"https://example.com"}]
+	],
+	"markups":[["strong"],["a",["href","https://ghost.org","rel","noopener"]],["em"],["script"]],
+	"sections":[
+		[1,"h2",[[0,[],0,"Title"]]],
+		[1,"p",[[0,[],0,"Hello "],[0,[0],1,"bold"],[0,[],0," and "],[0,[1,2],1,"linked "],[0,[],1,"text"],[1,[],0,0],[0,[3],1,"<x>"]]],
+		[3,"ul",[[[0,[],0,"one"]],[[0,[0],1,"two"]]]],
+		[1,"aside",[[0,[],0,"alt"]]],
+		[10,0],[10,1],[10,2],[10,3],[10,4],[10,5],
+		[2,"https://example.com/old.png"]
+	]}`
+
+func TestParse(t *testing.T) {
+	doc, err := ParseString(ghostDoc)
+	require.NoError(t, err)
+	assert.Equal(t, "0.3.1", doc.Version)
+	require.Len(t, doc.Markups, 4)
+	assert.Equal(t, "https://ghost.org", doc.Markups[1].Attr("href"))
+	assert.Equal(t, "noopener", doc.Markups[1].Attr("rel"))
+	assert.Empty(t, doc.Markups[1].Attr("title"))
+	assert.Equal(t, "soft-return", doc.Atoms[0].Name)
+	require.Len(t, doc.Cards, 6)
+	assert.Equal(t, "go", doc.Cards[1].Payload["language"])
+	require.Len(t, doc.Sections, 11)
+
+	p := doc.Sections[1].(*MarkupSection)
+	assert.Equal(t, "p", p.Tag)
+	assert.Equal(t, Marker{Text: "linked ", Open: []int{1, 2}, Close: 1}, p.Markers[3])
+	assert.Equal(t, Marker{IsAtom: true, Atom: 0, Open: []int{}}, p.Markers[5])
+
+	list := doc.Sections[2].(*ListSection)
+	assert.Equal(t, "ul", list.Tag)
+	assert.Len(t, list.Items, 2)
+	assert.Equal(t, &CardSection{Card: 5}, doc.Sections[9])
+	assert.Equal(t, &ImageSection{Src: "https://example.com/old.png"}, doc.Sections[10])
+}
+
+func TestDocument_RoundTrip(t *testing.T) {
+	doc, err := ParseString(ghostDoc)
+	require.NoError(t, err)
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	again, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, doc, again)
+}
+
+func TestDocument_Builders(t *testing.T) {
+	doc := New()
+	strong := doc.AddMarkup("strong")
+	assert.Equal(t, strong, doc.AddMarkup("strong"), "markups are reused")
+	link := doc.AddMarkup("a", "href", "https://example.com")
+	assert.NotEqual(t, link, doc.AddMarkup("a", "href", "https://example.org"))
+	br := doc.AddAtom("soft-return", "", nil)
+	doc.Append(
+		NewMarkupSection("p", TextMarker("hi", []int{strong}, 1), AtomMarker(br, nil, 0)),
+		NewListSection("ol", []Marker{TextMarker("one", nil, 0)}),
+		doc.AddCard("hr", nil),
+	)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"0.3.1",
+		"atoms":[["soft-return","",{}]],
+		"cards":[["hr",{}]],
+		"markups":[["strong"],["a",["href","https://example.com"]],["a",["href","https://example.org"]]],
+		"sections":[
+			[1,"p",[[0,[0],1,"hi"],[1,[],0,0]]],
+			[3,"ol",[[[0,[],0,"one"]]]],
+			[10,0]]}`, string(data))
+}
+
+func TestDocument_MarshalEmpty(t *testing.T) {
+	data, err := json.Marshal(&Document{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"0.3.1","atoms":[],"cards":[],"markups":[],"sections":[]}`, string(data))
+}
+
+func TestParse_SectionAttributes(t *testing.T) {
+	src := `{"version":"0.3.2","atoms":[],"cards":[],"markups":[],
+		"sections":[[1,"p",[[0,[],0,"centered"]],["data-md-text-align","center"]]]}`
+	doc, err := ParseString(src)
+	require.NoError(t, err)
+	p := doc.Sections[0].(*MarkupSection)
+	assert.Equal(t, []string{"data-md-text-align", "center"}, p.Attributes)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, src, string(data))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"old version", `{"version":"0.2.0","sections":[[],[]]}`, "unsupported version"},
+		{"missing card", `{"version":"0.3.1","sections":[[10,0]]}`, "missing card 0"},
+		{"missing markup", `{"version":"0.3.1","sections":[[1,"p",[[0,[2],1,"x"]]]]}`, "missing markup 2"},
+		{"missing atom", `{"version":"0.3.1","sections":[[1,"p",[[1,[],0,0]]]]}`, "missing atom 0"},
+		{"unknown section", `{"version":"0.3.1","sections":[[7,"x"]]}`, "unknown section type 7"},
+		{"unknown marker", `{"version":"0.3.1","sections":[[1,"p",[[5,[],0,"x"]]]]}`, "unknown marker type 5"},
+		{"invalid section", `{"version":"0.3.1","sections":[[1]]}`, "invalid section"},
+		{"invalid card", `{"version":"0.3.1","cards":[[1,2]]}`, "invalid card"},
+		{"invalid markup", `{"version":"0.3.1","markups":[[]]}`, "invalid markup"},
+		{"not json", `nope`, "invalid character"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseString(tt.src)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
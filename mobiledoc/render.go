@@ -0,0 +1,298 @@
+package mobiledoc
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+// markupTags are the inline markups rendered as HTML elements. Other markups
+// render as their contents only.
+var markupTags = map[string]bool{
+	"a": true, "b": true, "code": true, "em": true, "i": true,
+	"s": true, "strong": true, "sub": true, "sup": true, "u": true,
+}
+
+// linkAttributes are the markup attributes rendered on links.
+var linkAttributes = map[string]bool{"href": true, "rel": true, "target": true, "title": true}
+
+// HTML renders the document as HTML, following the markup Ghost produces for
+// Mobiledoc content. Unknown markups are reduced to their text and unknown
+// section tags render as paragraphs; unknown cards are skipped, as Ghost does.
+// HTML and embed cards are written as is.
+func (d *Document) HTML() string {
+	var b strings.Builder
+	for _, s := range d.Sections {
+		switch s := s.(type) {
+		case *MarkupSection:
+			open, close := "<p>", "</p>"
+			switch s.Tag {
+			case "h1", "h2", "h3", "h4", "h5", "h6", "blockquote":
+				open, close = "<"+s.Tag+">", "</"+s.Tag+">"
+			case "aside":
+				open, close = `<blockquote class="kg-blockquote-alt">`, "</blockquote>"
+			}
+			b.WriteString(open)
+			d.renderMarkers(&b, s.Markers)
+			b.WriteString(close)
+		case *ListSection:
+			tag := "ul"
+			if s.Tag == "ol" {
+				tag = "ol"
+			}
+			b.WriteString("<" + tag + ">")
+			for _, item := range s.Items {
+				b.WriteString("<li>")
+				d.renderMarkers(&b, item)
+				b.WriteString("</li>")
+			}
+			b.WriteString("</" + tag + ">")
+		case *ImageSection:
+			fmt.Fprintf(&b, `<img src="%s">`, html.EscapeString(s.Src))
+		case *CardSection:
+			if s.Card >= 0 && s.Card < len(d.Cards) {
+				renderCard(&b, d.Cards[s.Card])
+			}
+		}
+	}
+	return b.String()
+}
+
+// renderMarkers writes markers, opening and closing their markups.
+func (d *Document) renderMarkers(b *strings.Builder, markers []Marker) {
+	var stack []string
+	for _, m := range markers {
+		for _, i := range m.Open {
+			tag := ""
+			if i >= 0 && i < len(d.Markups) && markupTags[d.Markups[i].Tag] {
+				tag = d.Markups[i].Tag
+				b.WriteString("<" + tag)
+				attrs := d.Markups[i].Attributes
+				for j := 0; tag == "a" && j+1 < len(attrs); j += 2 {
+					if linkAttributes[attrs[j]] {
+						fmt.Fprintf(b, ` %s="%s"`, attrs[j], html.EscapeString(attrs[j+1]))
+					}
+				}
+				b.WriteString(">")
+			}
+			stack = append(stack, tag)
+		}
+
+		if !m.IsAtom {
+			b.WriteString(html.EscapeString(m.Text))
+		} else if m.Atom >= 0 && m.Atom < len(d.Atoms) {
+			if atom := d.Atoms[m.Atom]; atom.Name == "soft-return" {
+				b.WriteString("<br>")
+			} else {
+				b.WriteString(html.EscapeString(atom.Text))
+			}
+		}
+
+		for i := 0; i < m.Close && len(stack) > 0; i++ {
+			if tag := stack[len(stack)-1]; tag != "" {
+				b.WriteString("</" + tag + ">")
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] != "" {
+			b.WriteString("</" + stack[i] + ">")
+		}
+	}
+}
+
+// renderCard writes the HTML for one of Ghost's cards.
+func renderCard(b *strings.Builder, c Card) {
+	p := c.Payload
+	caption := str(p, "caption")
+	figcaption := func() {
+		if caption != "" {
+			b.WriteString("<figcaption>" + caption + "</figcaption>")
+		}
+	}
+	figure := func(classes string) {
+		if caption != "" {
+			classes += " kg-card-hascaption"
+		}
+		b.WriteString(`<figure class="kg-card ` + classes + `">`)
+	}
+
+	switch c.Name {
+	case "markdown":
+		md := blackfriday.Run([]byte(str(p, "markdown")), blackfriday.WithExtensions(
+			blackfriday.CommonExtensions|blackfriday.AutoHeadingIDs|blackfriday.NoEmptyLineBeforeBlock))
+		b.WriteString("<!--kg-card-begin: markdown-->")
+		b.Write(md)
+		b.WriteString("<!--kg-card-end: markdown-->")
+	case "html":
+		b.WriteString("<!--kg-card-begin: html-->" + str(p, "html") + "<!--kg-card-end: html-->")
+	case "hr":
+		b.WriteString("<hr>")
+	case "image":
+		classes := "kg-image-card"
+		if w := str(p, "cardWidth"); w == "wide" || w == "full" {
+			classes += " kg-width-" + w
+		}
+		figure(classes)
+		img := imageTag(p, "kg-image")
+		if href := str(p, "href"); href != "" {
+			img = `<a href="` + html.EscapeString(href) + `">` + img + "</a>"
+		}
+		b.WriteString(img)
+		figcaption()
+		b.WriteString("</figure>")
+	case "gallery":
+		figure("kg-gallery-card kg-width-wide")
+		b.WriteString(`<div class="kg-gallery-container">`)
+		images, _ := p["images"].([]interface{})
+		row := -1
+		for _, v := range images {
+			img, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if r := num(img, "row"); r != row {
+				if row >= 0 {
+					b.WriteString("</div>")
+				}
+				row = r
+				b.WriteString(`<div class="kg-gallery-row">`)
+			}
+			b.WriteString(`<div class="kg-gallery-image">` + imageTag(img, "") + "</div>")
+		}
+		if row >= 0 {
+			b.WriteString("</div>")
+		}
+		b.WriteString("</div>")
+		figcaption()
+		b.WriteString("</figure>")
+	case "code":
+		pre := "<pre><code"
+		if lang := str(p, "language"); lang != "" {
+			pre += ` class="language-` + html.EscapeString(lang) + `"`
+		}
+		pre += ">" + html.EscapeString(str(p, "code")) + "</code></pre>"
+		if caption == "" {
+			b.WriteString(pre)
+			return
+		}
+		figure("kg-code-card")
+		b.WriteString(pre)
+		figcaption()
+		b.WriteString("</figure>")
+	case "embed":
+		figure("kg-embed-card")
+		b.WriteString(str(p, "html"))
+		figcaption()
+		b.WriteString("</figure>")
+	case "bookmark":
+		meta, _ := p["metadata"].(map[string]interface{})
+		figure("kg-bookmark-card")
+		fmt.Fprintf(b, `<a class="kg-bookmark-container" href="%s"><div class="kg-bookmark-content">`,
+			html.EscapeString(str(p, "url")))
+		fmt.Fprintf(b, `<div class="kg-bookmark-title">%s</div>`, html.EscapeString(str(meta, "title")))
+		if desc := str(meta, "description"); desc != "" {
+			fmt.Fprintf(b, `<div class="kg-bookmark-description">%s</div>`, html.EscapeString(desc))
+		}
+		b.WriteString("</div></a>")
+		figcaption()
+		b.WriteString("</figure>")
+	case "callout":
+		color := str(p, "backgroundColor")
+		if color == "" {
+			color = "grey"
+		}
+		fmt.Fprintf(b, `<div class="kg-card kg-callout-card kg-callout-card-%s">`, html.EscapeString(color))
+		if emoji := str(p, "calloutEmoji"); emoji != "" {
+			b.WriteString(`<div class="kg-callout-emoji">` + html.EscapeString(emoji) + "</div>")
+		}
+		b.WriteString(`<div class="kg-callout-text">` + str(p, "calloutText") + "</div></div>")
+	}
+}
+
+// imageTag returns an img element for an image payload.
+func imageTag(p map[string]interface{}, class string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<img src="%s"`, html.EscapeString(str(p, "src")))
+	if class != "" {
+		fmt.Fprintf(&b, ` class="%s"`, class)
+	}
+	fmt.Fprintf(&b, ` alt="%s"`, html.EscapeString(str(p, "alt")))
+	if title := str(p, "title"); title != "" {
+		fmt.Fprintf(&b, ` title="%s"`, html.EscapeString(title))
+	}
+	b.WriteString(` loading="lazy"`)
+	if w, h := num(p, "width"), num(p, "height"); w > 0 && h > 0 {
+		fmt.Fprintf(&b, ` width="%d" height="%d"`, w, h)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// Text renders the document as plain text: one paragraph per section
+// separated by blank lines, list items on their own lines and soft returns
+// as newlines. Of the cards, only the contents of code and markdown cards
+// are included.
+func (d *Document) Text() string {
+	var blocks []string
+	for _, s := range d.Sections {
+		switch s := s.(type) {
+		case *MarkupSection:
+			blocks = append(blocks, d.markerText(s.Markers))
+		case *ListSection:
+			items := make([]string, len(s.Items))
+			for i, item := range s.Items {
+				items[i] = d.markerText(item)
+			}
+			blocks = append(blocks, strings.Join(items, "\n"))
+		case *CardSection:
+			if s.Card < 0 || s.Card >= len(d.Cards) {
+				continue
+			}
+			switch c := d.Cards[s.Card]; c.Name {
+			case "code":
+				blocks = append(blocks, str(c.Payload, "code"))
+			case "markdown":
+				blocks = append(blocks, strings.TrimSpace(str(c.Payload, "markdown")))
+			}
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// markerText returns the text of markers.
+func (d *Document) markerText(markers []Marker) string {
+	var b strings.Builder
+	for _, m := range markers {
+		switch {
+		case !m.IsAtom:
+			b.WriteString(m.Text)
+		case m.Atom < 0 || m.Atom >= len(d.Atoms):
+		case d.Atoms[m.Atom].Name == "soft-return":
+			b.WriteString("\n")
+		default:
+			b.WriteString(d.Atoms[m.Atom].Text)
+		}
+	}
+	return b.String()
+}
+
+// str returns the string value of key in p, or "".
+func str(p map[string]interface{}, key string) string {
+	s, _ := p[key].(string)
+	return s
+}
+
+// num returns the numeric value of key in p, or 0.
+func num(p map[string]interface{}, key string) int {
+	switch v := p[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,111 @@
+package mobiledoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_HTML(t *testing.T) {
+	doc, err := ParseString(ghostDoc)
+	require.NoError(t, err)
+	assert.Equal(t, `<h2>Title</h2>`+
+		`<p>Hello <strong>bold</strong> and <a href="https://ghost.org" rel="noopener"><em>linked </em>text</a><br>&lt;x&gt;</p>`+
+		`<ul><li>one</li><li><strong>two</strong></li></ul>`+
+		`<blockquote class="kg-blockquote-alt">alt</blockquote>`+
+		`<figure class="kg-card kg-image-card kg-width-wide kg-card-hascaption">`+
+		`<img src="https://example.com/a.png" class="kg-image" alt="A" loading="lazy"><figcaption>Cap <b>1</b></figcaption></figure>`+
+		`<pre><code class="language-go">x := 1</code></pre>`+
+		`<hr>`+
+		"<!--kg-card-begin: markdown--><p><strong>md</strong></p>\n<!--kg-card-end: markdown-->"+
+		`<figure class="kg-card kg-embed-card"><iframe></iframe></figure>`+
+		`<img src="https://example.com/old.png">`, doc.HTML())
+}
+
+func TestDocument_HTMLCards(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    string
+		payload map[string]interface{}
+		want    string
+	}{
+		{
+			name: "linked image with size",
+			card: "image",
+			payload: map[string]interface{}{"src": "a.png", "alt": `"q"`, "title": "T", "href": "https://x",
+				"width": float64(800), "height": float64(600)},
+			want: `<figure class="kg-card kg-image-card"><a href="https://x"><img src="a.png" class="kg-image" alt="&#34;q&#34;" title="T" loading="lazy" width="800" height="600"></a></figure>`,
+		},
+		{
+			name: "gallery",
+			card: "gallery",
+			payload: map[string]interface{}{"images": []interface{}{
+				map[string]interface{}{"row": float64(0), "src": "1.png"},
+				map[string]interface{}{"row": float64(0), "src": "2.png"},
+				map[string]interface{}{"row": float64(1), "src": "3.png"},
+			}, "caption": "Trip"},
+			want: `<figure class="kg-card kg-gallery-card kg-width-wide kg-card-hascaption"><div class="kg-gallery-container">` +
+				`<div class="kg-gallery-row"><div class="kg-gallery-image"><img src="1.png" alt="" loading="lazy"></div>` +
+				`<div class="kg-gallery-image"><img src="2.png" alt="" loading="lazy"></div></div>` +
+				`<div class="kg-gallery-row"><div class="kg-gallery-image"><img src="3.png" alt="" loading="lazy"></div></div>` +
+				`</div><figcaption>Trip</figcaption></figure>`,
+		},
+		{
+			name:    "code with caption",
+			card:    "code",
+			payload: map[string]interface{}{"code": "<b>", "caption": "Example"},
+			want:    `<figure class="kg-card kg-code-card kg-card-hascaption"><pre><code>&lt;b&gt;</code></pre><figcaption>Example</figcaption></figure>`,
+		},
+		{
+			name: "bookmark",
+			card: "bookmark",
+			payload: map[string]interface{}{"url": "https://ghost.org",
+				"metadata": map[string]interface{}{"title": "Ghost", "description": "Publishing"}},
+			want: `<figure class="kg-card kg-bookmark-card"><a class="kg-bookmark-container" href="https://ghost.org">` +
+				`<div class="kg-bookmark-content"><div class="kg-bookmark-title">Ghost</div>` +
+				`<div class="kg-bookmark-description">Publishing</div></div></a></figure>`,
+		},
+		{
+			name:    "callout",
+			card:    "callout",
+			payload: map[string]interface{}{"calloutEmoji": "💡", "calloutText": "<b>Tip</b>", "backgroundColor": "blue"},
+			want:    `<div class="kg-card kg-callout-card kg-callout-card-blue"><div class="kg-callout-emoji">💡</div><div class="kg-callout-text"><b>Tip</b></div></div>`,
+		},
+		{
+			name:    "html",
+			card:    "html",
+			payload: map[string]interface{}{"html": "<div>raw</div>"},
+			want:    `<!--kg-card-begin: html--><div>raw</div><!--kg-card-end: html-->`,
+		},
+		{
+			name: "unknown",
+			card: "paywall",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := New()
+			doc.Append(doc.AddCard(tt.card, tt.payload))
+			assert.Equal(t, tt.want, doc.HTML())
+		})
+	}
+}
+
+func TestDocument_HTMLUnbalancedMarkers(t *testing.T) {
+	doc := New()
+	em := doc.AddMarkup("em")
+	doc.Append(
+		NewMarkupSection("p", TextMarker("open", []int{em}, 0)),
+		NewMarkupSection("h9", TextMarker("x", nil, 3), AtomMarker(7, []int{9}, 0)),
+	)
+	assert.Equal(t, `<p><em>open</em></p><p>x</p>`, doc.HTML())
+}
+
+func TestDocument_Text(t *testing.T) {
+	doc, err := ParseString(ghostDoc)
+	require.NoError(t, err)
+	assert.Equal(t, "Title\n\nHello bold and linked text\n<x>\n\none\ntwo\n\nalt\n\nx := 1\n\n**md**", doc.Text())
+	assert.Empty(t, New().Text())
+}
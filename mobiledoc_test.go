@@ -0,0 +1,68 @@
+package libecto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/visionik/libecto/mobiledoc"
+)
+
+func TestPost_SetMobiledoc(t *testing.T) {
+	post := &Post{Title: "T", HTML: "<p>old</p>", Lexical: `{"root":{}}`}
+	doc := mobiledoc.New()
+	doc.Append(mobiledoc.NewMarkupSection("p", mobiledoc.TextMarker("Hello", nil, 0)))
+	require.NoError(t, post.SetMobiledoc(doc))
+	assert.Empty(t, post.HTML)
+	assert.Empty(t, post.Lexical)
+	assert.Contains(t, post.Mobiledoc, `"Hello"`)
+
+	got, err := post.MobiledocDocument()
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Hello</p>", got.HTML())
+
+	empty, err := (&Post{}).MobiledocDocument()
+	require.NoError(t, err)
+	assert.Empty(t, empty.Sections)
+
+	_, err = (&Post{Mobiledoc: `{"version":"0.2.0"}`}).MobiledocDocument()
+	assert.ErrorContains(t, err, "failed to decode mobiledoc")
+}
+
+func TestPage_SetMobiledoc(t *testing.T) {
+	page := &Page{HTML: "<p>old</p>"}
+	doc := mobiledoc.New()
+	doc.Append(doc.AddCard("hr", nil))
+	require.NoError(t, page.SetMobiledoc(doc))
+	assert.Empty(t, page.HTML)
+
+	got, err := page.MobiledocDocument()
+	require.NoError(t, err)
+	assert.Equal(t, "<hr>", got.HTML())
+}
+
+func TestClient_WriteMobiledoc(t *testing.T) {
+	var query string
+	var body map[string][]map[string]interface{}
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{{ID: "1"}}})
+	})
+	defer server.Close()
+
+	post := &Post{Title: "Mobiledoc"}
+	require.NoError(t, post.SetMobiledoc(MarkdownStringToMobiledoc("Hello **world**")))
+	_, err := client.CreatePostContext(context.Background(), post)
+	require.NoError(t, err)
+
+	assert.Equal(t, "formats=html", query)
+	sent, err := mobiledoc.ParseString(body["posts"][0]["mobiledoc"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Hello <strong>world</strong></p>", sent.HTML())
+	assert.NotContains(t, body["posts"][0], "lexical")
+}
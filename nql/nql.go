@@ -0,0 +1,219 @@
+// Package nql builds filter expressions in NQL, the query language Ghost uses
+// for the filter parameter of list endpoints.
+//
+// Expressions are composed from comparisons and combined with And and Or;
+// values are quoted and escaped as needed:
+//
+//	f := nql.And(
+//		nql.Eq("tag", "news"),
+//		nql.Eq("featured", true),
+//		nql.Or(nql.Gt("published_at", cutoff), nql.In("authors.slug", "a", "b")),
+//	)
+//	posts, err := content.ListPosts(&libecto.ListOptions{Filter: f.String()})
+//
+// f.String() renders as tag:news+featured:true+(published_at:>'2024-01-01 00:00:00',authors.slug:[a,b]).
+package nql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeFormat is the layout used for time.Time values. Times are converted to UTC.
+const TimeFormat = "2006-01-02 15:04:05"
+
+// kind distinguishes single comparisons from compound expressions,
+// which need parentheses when nested in a conjunction.
+type kind int
+
+const (
+	kindEmpty kind = iota
+	kindTerm
+	kindAnd
+	kindOr
+)
+
+// Expr is an NQL filter expression. The zero Expr is empty: it renders as ""
+// and is skipped by And and Or, which makes optional conditions easy to build.
+type Expr struct {
+	kind kind
+	s    string
+}
+
+// String returns the expression in NQL syntax.
+func (e Expr) String() string {
+	return e.s
+}
+
+// IsZero reports whether the expression is empty.
+func (e Expr) IsZero() bool {
+	return e.kind == kindEmpty
+}
+
+// Raw returns an expression for an already formatted NQL string.
+// It is treated as a single term, so it is not parenthesized when combined;
+// wrap compound strings in parentheses yourself.
+func Raw(s string) Expr {
+	if s == "" {
+		return Expr{}
+	}
+	return Expr{kind: kindTerm, s: s}
+}
+
+// Eq matches items whose field equals value (field:value).
+// For relations such as tags this matches if any related item has the value.
+func Eq(field string, value interface{}) Expr {
+	return compare(field, "", value)
+}
+
+// Ne matches items whose field does not equal value (field:-value).
+func Ne(field string, value interface{}) Expr {
+	return compare(field, "-", value)
+}
+
+// Gt matches items whose field is greater than value (field:>value).
+func Gt(field string, value interface{}) Expr {
+	return compare(field, ">", value)
+}
+
+// Gte matches items whose field is greater than or equal to value (field:>=value).
+func Gte(field string, value interface{}) Expr {
+	return compare(field, ">=", value)
+}
+
+// Lt matches items whose field is less than value (field:<value).
+func Lt(field string, value interface{}) Expr {
+	return compare(field, "<", value)
+}
+
+// Lte matches items whose field is less than or equal to value (field:<=value).
+func Lte(field string, value interface{}) Expr {
+	return compare(field, "<=", value)
+}
+
+// Contains matches items whose field contains the substring s (field:~'s').
+func Contains(field, s string) Expr {
+	return compare(field, "~", s)
+}
+
+// NotContains matches items whose field does not contain the substring s (field:-~'s').
+func NotContains(field, s string) Expr {
+	return compare(field, "-~", s)
+}
+
+// StartsWith matches items whose field starts with s (field:~^'s').
+func StartsWith(field, s string) Expr {
+	return compare(field, "~^", s)
+}
+
+// EndsWith matches items whose field ends with s (field:~$'s').
+func EndsWith(field, s string) Expr {
+	return compare(field, "~$", s)
+}
+
+// In matches items whose field equals any of values (field:[a,b]).
+func In(field string, values ...interface{}) Expr {
+	return list(field, "", values)
+}
+
+// NotIn matches items whose field equals none of values (field:-[a,b]).
+func NotIn(field string, values ...interface{}) Expr {
+	return list(field, "-", values)
+}
+
+// And matches items that match every expression (a+b). Empty expressions are
+// skipped, and disjunctions are parenthesized to keep their grouping.
+func And(exprs ...Expr) Expr {
+	return combine(kindAnd, "+", exprs)
+}
+
+// Or matches items that match any of the expressions (a,b). Empty expressions
+// are skipped, and conjunctions are parenthesized to keep their grouping.
+func Or(exprs ...Expr) Expr {
+	return combine(kindOr, ",", exprs)
+}
+
+func compare(field, op string, value interface{}) Expr {
+	return Expr{kind: kindTerm, s: field + ":" + op + Value(value)}
+}
+
+func list(field, op string, values []interface{}) Expr {
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = Value(v)
+	}
+	return Expr{kind: kindTerm, s: field + ":" + op + "[" + strings.Join(formatted, ",") + "]"}
+}
+
+func combine(k kind, sep string, exprs []Expr) Expr {
+	var parts []string
+	var last Expr
+	for _, e := range exprs {
+		if e.IsZero() {
+			continue
+		}
+		last = e
+		if e.kind != kindTerm && e.kind != k {
+			parts = append(parts, "("+e.s+")")
+		} else {
+			parts = append(parts, e.s)
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return Expr{}
+	case 1:
+		return last
+	}
+	return Expr{kind: k, s: strings.Join(parts, sep)}
+}
+
+// literal matches strings that NQL parses as a bare literal: slugs, field
+// names and words that do not start with a digit or an operator.
+var literal = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// Value formats a value for use in an NQL expression. Booleans, nil and
+// numbers are written as NQL literals, times are quoted in TimeFormat, and
+// strings are left bare when they are simple slugs and quoted otherwise.
+// Other types are formatted with fmt.Sprint and quoted.
+func Value(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(val)
+	case int:
+		return strconv.Itoa(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case int32, int16, int8, uint, uint64, uint32, uint16, uint8:
+		return fmt.Sprint(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case time.Time:
+		return Quote(val.UTC().Format(TimeFormat))
+	case string:
+		switch strings.ToLower(val) {
+		case "true", "false", "null":
+			return Quote(val)
+		}
+		if literal.MatchString(val) {
+			return val
+		}
+		return Quote(val)
+	default:
+		return Quote(fmt.Sprint(val))
+	}
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// Quote returns s as a single-quoted NQL string, escaping quotes and backslashes.
+func Quote(s string) string {
+	return "'" + quoteEscaper.Replace(s) + "'"
+}
@@ -0,0 +1,98 @@
+package nql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComparisons(t *testing.T) {
+	cutoff := time.Date(2024, 1, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		expr Expr
+		want string
+	}{
+		{Eq("tag", "news"), "tag:news"},
+		{Eq("featured", true), "featured:true"},
+		{Eq("feature_image", nil), "feature_image:null"},
+		{Ne("status", "draft"), "status:-draft"},
+		{Gt("published_at", cutoff), "published_at:>'2024-01-01 11:30:00'"},
+		{Gte("count.posts", 5), "count.posts:>=5"},
+		{Lt("reading_time", 2.5), "reading_time:<2.5"},
+		{Lte("published_at", "2024-01-01"), "published_at:<='2024-01-01'"},
+		{Contains("title", "it's"), `title:~'it\'s'`},
+		{NotContains("title", "draft"), "title:-~draft"},
+		{StartsWith("slug", "how-to"), "slug:~^how-to"},
+		{EndsWith("slug", "-2"), "slug:~$'-2'"},
+		{In("authors.slug", "a", "b"), "authors.slug:[a,b]"},
+		{NotIn("tag", "news", "hash tag"), "tag:-[news,'hash tag']"},
+		{Raw("tag:news"), "tag:news"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.expr.String())
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"news", "news"},
+		{"my-tag", "my-tag"},
+		{"", "''"},
+		{"two words", "'two words'"},
+		{"-negated", "'-negated'"},
+		{"2024", "'2024'"},
+		{"true", "'true'"},
+		{"NULL", "'NULL'"},
+		{"a+b,c", "'a+b,c'"},
+		{"[list]", "'[list]'"},
+		{`back\slash`, `'back\\slash'`},
+		{"#internal", "'#internal'"},
+		{-3, "-3"},
+		{uint8(7), "7"},
+		{int64(1) << 40, "1099511627776"},
+		{false, "false"},
+		{[]int{1}, "'[1]'"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Value(tt.value), "Value(%#v)", tt.value)
+	}
+}
+
+func TestAndOr(t *testing.T) {
+	a, b, c := Eq("tag", "news"), Eq("featured", true), Eq("status", "published")
+
+	assert.Equal(t, "tag:news+featured:true", And(a, b).String())
+	assert.Equal(t, "tag:news,featured:true", Or(a, b).String())
+
+	// Nested expressions of the same kind are flattened.
+	assert.Equal(t, "tag:news+featured:true+status:published", And(And(a, b), c).String())
+	assert.Equal(t, "tag:news,featured:true,status:published", Or(a, Or(b, c)).String())
+
+	// Mixed expressions are grouped.
+	assert.Equal(t, "tag:news+(featured:true,status:published)", And(a, Or(b, c)).String())
+	assert.Equal(t, "(tag:news+featured:true),status:published", Or(And(a, b), c).String())
+	assert.Equal(t, "status:published+((tag:news+featured:true),tag:news)", And(c, Or(And(a, b), a)).String())
+}
+
+func TestEmpty(t *testing.T) {
+	var zero Expr
+	assert.True(t, zero.IsZero())
+	assert.Equal(t, "", zero.String())
+	assert.True(t, Raw("").IsZero())
+
+	a := Eq("tag", "news")
+	assert.Equal(t, a, And(zero, a, zero))
+	assert.Equal(t, a, Or(a))
+	assert.True(t, And().IsZero())
+	assert.True(t, Or(zero, zero).IsZero())
+
+	// A single remaining operand keeps its own kind for grouping.
+	or := Or(zero, Or(Eq("a", 1), Eq("b", 2)))
+	assert.Equal(t, "c:3+(a:1,b:2)", And(Eq("c", 3), or).String())
+}
@@ -0,0 +1,193 @@
+package libecto
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// LimitAll is the ListOptions.Limit that requests every item in a single page
+// (limit=all). Pagination.Limit reports it when Ghost returns all items.
+const LimitAll = -1
+
+// Formats that can be requested for post and page content.
+const (
+	FormatHTML      = "html"
+	FormatLexical   = "lexical"
+	FormatMobiledoc = "mobiledoc"
+	FormatPlaintext = "plaintext"
+)
+
+// Related data that can be embedded with Include.
+const (
+	IncludeTags       = "tags"
+	IncludeAuthors    = "authors"
+	IncludeCountPosts = "count.posts"
+	IncludeTiers      = "tiers"
+	IncludeEmail      = "email"
+	IncludeNewsletter = "newsletter"
+)
+
+// queryOptions is implemented by option types that encode to query parameters.
+type queryOptions interface {
+	values() url.Values
+}
+
+// ListOptions are the query parameters accepted by list endpoints.
+// The zero value requests the server defaults.
+type ListOptions struct {
+	// Filter is an NQL filter expression (e.g., "tag:getting-started+featured:true").
+	Filter string
+	// Include lists related data to embed (e.g., "tags", "authors", "count.posts").
+	Include []string
+	// Fields restricts the returned fields (e.g., "id", "title", "url").
+	Fields []string
+	// Formats lists the content formats to return for posts and pages
+	// (e.g., FormatHTML, FormatLexical). Admin post and page endpoints
+	// default to FormatHTML when empty.
+	Formats []string
+	// Order is the sort order (e.g., "published_at desc").
+	Order string
+	// Page is the 1-indexed page number; 0 requests the first page.
+	Page int
+	// Limit is the page size; 0 requests the server default and LimitAll
+	// requests every item.
+	Limit int
+}
+
+// values encodes the options as query parameters.
+func (o *ListOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+	if o.Filter != "" {
+		v.Set("filter", o.Filter)
+	}
+	if len(o.Include) > 0 {
+		v.Set("include", strings.Join(o.Include, ","))
+	}
+	if len(o.Fields) > 0 {
+		v.Set("fields", strings.Join(o.Fields, ","))
+	}
+	if len(o.Formats) > 0 {
+		v.Set("formats", strings.Join(o.Formats, ","))
+	}
+	if o.Order != "" {
+		v.Set("order", o.Order)
+	}
+	if o.Page > 0 {
+		v.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Limit > 0 {
+		v.Set("limit", strconv.Itoa(o.Limit))
+	} else if o.Limit == LimitAll {
+		v.Set("limit", "all")
+	}
+	return v
+}
+
+// GetOptions are the query parameters accepted by single-resource endpoints.
+type GetOptions struct {
+	// Include lists related data to embed (e.g., "tags", "authors").
+	Include []string
+	// Fields restricts the returned fields.
+	Fields []string
+	// Formats lists the content formats to return for posts and pages.
+	// Admin post and page endpoints default to FormatHTML when empty.
+	Formats []string
+}
+
+// values encodes the options as query parameters.
+func (o *GetOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+	if len(o.Include) > 0 {
+		v.Set("include", strings.Join(o.Include, ","))
+	}
+	if len(o.Fields) > 0 {
+		v.Set("fields", strings.Join(o.Fields, ","))
+	}
+	if len(o.Formats) > 0 {
+		v.Set("formats", strings.Join(o.Formats, ","))
+	}
+	return v
+}
+
+// Email segments for PublishOptions.EmailSegment. Any NQL member filter is
+// accepted, such as nql.Eq("label", "vip").String() for a label.
+const (
+	EmailSegmentAll  = "all"
+	EmailSegmentFree = "status:free"
+	EmailSegmentPaid = "status:-free"
+)
+
+// PublishOptions control how PublishPostWithOptions publishes a post.
+// The zero value publishes to the site without sending an email.
+type PublishOptions struct {
+	// Newsletter is the slug of the newsletter to email the post with.
+	// No email is sent when it is empty.
+	Newsletter string
+	// EmailSegment is the member filter to email (e.g., EmailSegmentFree).
+	// Ghost emails every subscriber of the newsletter when it is empty.
+	EmailSegment string
+	// EmailOnly sends the post as an email without publishing it on the
+	// site. It requires Newsletter.
+	EmailOnly bool
+}
+
+// values encodes the options as query parameters.
+func (o *PublishOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+	if o.Newsletter != "" {
+		v.Set("newsletter", o.Newsletter)
+		v.Set("include", strings.Join([]string{IncludeEmail, IncludeNewsletter}, ","))
+	}
+	if o.EmailSegment != "" {
+		v.Set("email_segment", o.EmailSegment)
+	}
+	return v
+}
+
+// contentQuery encodes opts for a post or page endpoint, requesting HTML
+// unless other formats are given.
+func contentQuery(opts queryOptions) url.Values {
+	v := opts.values()
+	if v.Get("formats") == "" {
+		v.Set("formats", FormatHTML)
+	}
+	return v
+}
+
+// editFormats are the formats admin single-resource getters return by
+// default: HTML for display and the editor formats, so that a fetched post
+// or page sent back to an update keeps its content rather than having Ghost
+// re-import it from HTML.
+var editFormats = []string{FormatHTML, FormatLexical, FormatMobiledoc}
+
+// editQuery encodes opts for an admin post or page lookup, requesting
+// editFormats unless other formats are given.
+func editQuery(opts *GetOptions) url.Values {
+	v := opts.values()
+	if v.Get("formats") == "" {
+		v.Set("formats", strings.Join(editFormats, ","))
+	}
+	return v
+}
+
+// withQuery appends encoded query parameters to path.
+func withQuery(path string, v url.Values) string {
+	if len(v) == 0 {
+		return path
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + v.Encode()
+}
@@ -0,0 +1,77 @@
+package libecto
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListOptions_Values(t *testing.T) {
+	var nilOpts *ListOptions
+	assert.Empty(t, nilOpts.values())
+	assert.Empty(t, (&ListOptions{}).values())
+
+	v := (&ListOptions{
+		Filter:  "status:published",
+		Include: []string{"tags", "authors"},
+		Fields:  []string{"id", "title"},
+		Formats: []string{FormatHTML, FormatLexical},
+		Order:   "title asc",
+		Page:    3,
+		Limit:   10,
+	}).values()
+	assert.Equal(t, url.Values{
+		"filter":  {"status:published"},
+		"include": {"tags,authors"},
+		"fields":  {"id,title"},
+		"formats": {"html,lexical"},
+		"order":   {"title asc"},
+		"page":    {"3"},
+		"limit":   {"10"},
+	}, v)
+
+	assert.Equal(t, url.Values{"limit": {"all"}}, (&ListOptions{Limit: LimitAll}).values())
+	assert.Empty(t, (&ListOptions{Limit: -5}).values())
+}
+
+func TestGetOptions_Values(t *testing.T) {
+	var nilOpts *GetOptions
+	assert.Empty(t, nilOpts.values())
+
+	v := (&GetOptions{Include: []string{"tags"}, Fields: []string{"id"}, Formats: []string{FormatMobiledoc}}).values()
+	assert.Equal(t, url.Values{"include": {"tags"}, "fields": {"id"}, "formats": {"mobiledoc"}}, v)
+}
+
+func TestPublishOptions_Values(t *testing.T) {
+	var nilOpts *PublishOptions
+	assert.Empty(t, nilOpts.values())
+	assert.Empty(t, (&PublishOptions{}).values())
+
+	v := (&PublishOptions{Newsletter: "weekly", EmailSegment: EmailSegmentPaid}).values()
+	assert.Equal(t, url.Values{
+		"newsletter":    {"weekly"},
+		"email_segment": {"status:-free"},
+		"include":       {"email,newsletter"},
+	}, v)
+}
+
+func TestContentQuery(t *testing.T) {
+	assert.Equal(t, url.Values{"formats": {"html"}}, contentQuery((*ListOptions)(nil)))
+	assert.Equal(t, url.Values{"formats": {"html"}, "limit": {"5"}}, contentQuery(&ListOptions{Limit: 5}))
+	assert.Equal(t, url.Values{"formats": {"lexical"}},
+		contentQuery(&GetOptions{Formats: []string{FormatLexical}}))
+}
+
+func TestEditQuery(t *testing.T) {
+	assert.Equal(t, url.Values{"formats": {"html,lexical,mobiledoc"}}, editQuery(nil))
+	assert.Equal(t, url.Values{"formats": {"lexical"}, "include": {"tags"}},
+		editQuery(&GetOptions{Formats: []string{FormatLexical}, Include: []string{"tags"}}))
+}
+
+func TestWithQuery(t *testing.T) {
+	assert.Equal(t, "/posts/", withQuery("/posts/", nil))
+	assert.Equal(t, "/posts/?limit=5", withQuery("/posts/", url.Values{"limit": {"5"}}))
+	assert.Equal(t, "/posts/?formats=html&filter=tag%3Anews",
+		withQuery("/posts/?formats=html", url.Values{"filter": {"tag:news"}}))
+}
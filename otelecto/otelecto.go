@@ -0,0 +1,173 @@
+// Package otelecto provides OpenTelemetry instrumentation for libecto clients.
+//
+// Every API call made through an instrumented Client produces a client span
+// named after the operation (for example "ghost.posts.update") and records
+// request count, latency and error metrics per operation:
+//
+//	client := libecto.NewClient(url, key, otelecto.Instrument())
+//
+// By default the global TracerProvider, MeterProvider and propagators are used.
+package otelecto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/visionik/libecto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name used for tracers and meters.
+const ScopeName = "github.com/visionik/libecto/otelecto"
+
+// Attribute keys recorded on spans and metrics.
+const (
+	AttrOperation  = attribute.Key("ghost.operation")
+	AttrErrorType  = attribute.Key("ghost.error_type")
+	AttrMethod     = attribute.Key("http.request.method")
+	AttrStatusCode = attribute.Key("http.response.status_code")
+	AttrPath       = attribute.Key("url.path")
+)
+
+// config holds the instrumentation settings.
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider used to create spans.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to record metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagators used to inject trace context into request headers.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// Instrument returns a libecto.ClientOption that installs the instrumentation middleware.
+func Instrument(opts ...Option) libecto.ClientOption {
+	return libecto.WithMiddleware(NewMiddleware(opts...))
+}
+
+// NewMiddleware returns a libecto.Middleware that traces and measures every API call.
+// Install it first so that spans cover retries and rate limiting.
+func NewMiddleware(opts ...Option) libecto.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	meter := cfg.meterProvider.Meter(ScopeName)
+
+	requests, err := meter.Int64Counter("ghost.client.requests",
+		metric.WithDescription("Number of Ghost API calls."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	errs, err := meter.Int64Counter("ghost.client.errors",
+		metric.WithDescription("Number of Ghost API calls that failed."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	duration, err := meter.Float64Histogram("ghost.client.duration",
+		metric.WithDescription("Duration of Ghost API calls, including retries."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next libecto.Handler) libecto.Handler {
+		return func(ctx context.Context, req *libecto.Request) (*libecto.Response, error) {
+			op := req.Operation()
+			path, _, _ := strings.Cut(req.Path, "?")
+
+			ctx, span := tracer.Start(ctx, "ghost."+op,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					AttrOperation.String(op),
+					AttrMethod.String(req.Method),
+					AttrPath.String(path),
+				),
+			)
+			defer span.End()
+
+			if req.Header == nil {
+				req.Header = http.Header{}
+			}
+			cfg.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+			elapsed := time.Since(start).Seconds()
+
+			attrs := []attribute.KeyValue{
+				AttrOperation.String(op),
+				AttrMethod.String(req.Method),
+			}
+			failed := err != nil
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				attrs = append(attrs, AttrStatusCode.Int(resp.StatusCode))
+				span.SetAttributes(AttrStatusCode.Int(resp.StatusCode))
+				if resp.StatusCode >= 400 {
+					failed = true
+					if errType := errorType(resp.Body); errType != "" {
+						attrs = append(attrs, AttrErrorType.String(errType))
+						span.SetAttributes(AttrErrorType.String(errType))
+					}
+					span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+				}
+			}
+
+			set := metric.WithAttributes(attrs...)
+			requests.Add(ctx, 1, set)
+			duration.Record(ctx, elapsed, set)
+			if failed {
+				errs.Add(ctx, 1, set)
+			}
+			return resp, err
+		}
+	}
+}
+
+// errorType extracts the Ghost error type from an error response body.
+func errorType(body []byte) string {
+	var errResp libecto.ErrorResponse
+	if json.Unmarshal(body, &errResp) != nil || len(errResp.Errors) == 0 {
+		return ""
+	}
+	return errResp.Errors[0].Type
+}
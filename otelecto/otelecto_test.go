@@ -0,0 +1,174 @@
+package otelecto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visionik/libecto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testAPIKey = "test123:7365637265746b6579313233"
+
+type testEnv struct {
+	spans  *tracetest.SpanRecorder
+	reader *sdkmetric.ManualReader
+	client *libecto.Client
+}
+
+func newTestEnv(t *testing.T, handler http.HandlerFunc) *testEnv {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	client := libecto.NewClient(server.URL, testAPIKey, Instrument(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		WithPropagators(propagation.TraceContext{}),
+	))
+	return &testEnv{spans: spans, reader: reader, client: client}
+}
+
+func (e *testEnv) metrics(t *testing.T) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, e.reader.Collect(context.Background(), &rm))
+	out := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			out[m.Name] = m.Data
+		}
+	}
+	return out
+}
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	out := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		out[kv.Key] = kv.Value
+	}
+	return out
+}
+
+func TestInstrument_Span(t *testing.T) {
+	env := newTestEnv(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Traceparent"))
+		w.Write([]byte(`{"posts":[{"id":"123","title":"Updated"}]}`))
+	})
+
+	_, err := env.client.UpdatePost("123", &libecto.Post{Title: "Updated"})
+	require.NoError(t, err)
+
+	spans := env.spans.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "ghost.posts.update", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+
+	attrs := spanAttrs(span)
+	assert.Equal(t, "posts.update", attrs[AttrOperation].AsString())
+	assert.Equal(t, "PUT", attrs[AttrMethod].AsString())
+	assert.Equal(t, "/posts/123/", attrs[AttrPath].AsString())
+	assert.Equal(t, int64(200), attrs[AttrStatusCode].AsInt64())
+}
+
+func TestInstrument_ErrorResponse(t *testing.T) {
+	env := newTestEnv(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(libecto.ErrorResponse{Errors: []libecto.APIError{{
+			Message: "Saving failed", Type: libecto.ErrorTypeUpdateCollision,
+		}}})
+	})
+
+	_, err := env.client.UpdatePost("123", &libecto.Post{Title: "Stale"})
+	require.Error(t, err)
+
+	spans := env.spans.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	attrs := spanAttrs(spans[0])
+	assert.Equal(t, libecto.ErrorTypeUpdateCollision, attrs[AttrErrorType].AsString())
+	assert.Equal(t, int64(409), attrs[AttrStatusCode].AsInt64())
+
+	metrics := env.metrics(t)
+	errs, ok := metrics["ghost.client.errors"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, errs.DataPoints, 1)
+	assert.Equal(t, int64(1), errs.DataPoints[0].Value)
+	errType, _ := errs.DataPoints[0].Attributes.Value(AttrErrorType)
+	assert.Equal(t, libecto.ErrorTypeUpdateCollision, errType.AsString())
+}
+
+func TestInstrument_TransportError(t *testing.T) {
+	env := newTestEnv(t, func(w http.ResponseWriter, r *http.Request) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := env.client.GetSiteContext(ctx)
+	require.Error(t, err)
+
+	spans := env.spans.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "ghost.site.get", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.NotEmpty(t, spans[0].Events())
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
+
+func TestInstrument_Metrics(t *testing.T) {
+	env := newTestEnv(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"posts":[],"tags":[]}`))
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := env.client.ListPosts("", 0)
+		require.NoError(t, err)
+	}
+	_, err := env.client.ListTags(0)
+	require.NoError(t, err)
+
+	metrics := env.metrics(t)
+
+	requests, ok := metrics["ghost.client.requests"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	counts := map[string]int64{}
+	for _, dp := range requests.DataPoints {
+		op, _ := dp.Attributes.Value(AttrOperation)
+		counts[op.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{"posts.list": 3, "tags.list": 1}, counts)
+
+	duration, ok := metrics["ghost.client.duration"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	assert.Len(t, duration.DataPoints, 2)
+
+	_, hasErrors := metrics["ghost.client.errors"]
+	assert.False(t, hasErrors)
+}
+
+func TestInstrument_Upload(t *testing.T) {
+	env := newTestEnv(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"images":[{"url":"https://example.com/a.jpg"}]}`))
+	})
+
+	_, err := env.client.UploadImageReader(strings.NewReader("data"), "a.jpg")
+	require.NoError(t, err)
+
+	spans := env.spans.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "ghost.images.upload", spans[0].Name())
+}
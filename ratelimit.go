@@ -0,0 +1,131 @@
+package libecto
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token-bucket limiter that spaces out API requests.
+// A single RateLimiter is safe for concurrent use and may be shared by several
+// Clients so that they draw from the same budget.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu        sync.Mutex
+	tokens    float64
+	last      time.Time
+	requests  int64
+	delayed   int64
+	totalWait time.Duration
+}
+
+// RateLimiterStats reports how much a RateLimiter has delayed requests.
+type RateLimiterStats struct {
+	// Requests is the number of requests that passed through the limiter.
+	Requests int64
+	// Delayed is the number of requests that had to wait.
+	Delayed int64
+	// TotalWait is the cumulative time requests spent waiting.
+	TotalWait time.Duration
+}
+
+// NewRateLimiter creates a limiter allowing requestsPerSecond on average,
+// with bursts of up to burst requests. A burst below 1 is treated as 1.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// WithRateLimit limits the client to requestsPerSecond with bursts of up to burst requests.
+// Every endpoint, including image uploads and each retry attempt, draws from the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return WithRateLimiter(NewRateLimiter(requestsPerSecond, burst))
+}
+
+// WithRateLimiter uses an existing RateLimiter, which may be shared with other clients.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// Wait blocks until a request may proceed or ctx is done.
+// It returns how long the caller was delayed.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	delay := l.reserve()
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return 0, ctx.Err()
+	case <-timer.C:
+	}
+
+	l.mu.Lock()
+	l.delayed++
+	l.totalWait += delay
+	l.mu.Unlock()
+	return delay, nil
+}
+
+// Stats returns a snapshot of the limiter's counters.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return RateLimiterStats{
+		Requests:  l.requests,
+		Delayed:   l.delayed,
+		TotalWait: l.totalWait,
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait before using it.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.requests++
+	if l.rate <= 0 {
+		return 0
+	}
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token to the bucket when the caller gives up waiting.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
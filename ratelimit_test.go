@@ -0,0 +1,136 @@
+package libecto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(2, 2)
+	l.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, 500*time.Millisecond, l.reserve())
+	assert.Equal(t, time.Second, l.reserve())
+
+	// After one second the debt is repaid; after another the bucket is full again.
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, 500*time.Millisecond, l.reserve())
+}
+
+func TestRateLimiter_RefillCappedAtBurst(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(10, 3)
+	l.now = func() time.Time { return now }
+
+	l.reserve()
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, time.Duration(0), l.reserve())
+	}
+	assert.Equal(t, 100*time.Millisecond, l.reserve())
+}
+
+func TestRateLimiter_ZeroRateUnlimited(t *testing.T) {
+	l := NewRateLimiter(0, 0)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, time.Duration(0), l.reserve())
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := NewRateLimiter(50, 1)
+	ctx := context.Background()
+
+	wait, err := l.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+
+	start := time.Now()
+	wait, err = l.Wait(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, wait, time.Duration(0))
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+
+	stats := l.Stats()
+	assert.Equal(t, int64(2), stats.Requests)
+	assert.Equal(t, int64(1), stats.Delayed)
+	assert.Equal(t, wait, stats.TotalWait)
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	_, err := l.Wait(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = l.Wait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int64(0), l.Stats().Delayed)
+}
+
+func TestClient_WithRateLimit(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if strings.Contains(r.URL.Path, "/images/upload/") {
+			w.Write([]byte(`{"images":[{"url":"https://example.com/a.jpg"}]}`))
+			return
+		}
+		w.Write([]byte(`{"posts":[{"id":"1"}]}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(100, 1)
+	client := NewClient(server.URL, testAPIKey, WithRateLimiter(limiter))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.UpdatePost("1", &Post{Title: "x"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	_, err := client.UploadImageReader(strings.NewReader("data"), "a.jpg")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+	stats := limiter.Stats()
+	assert.Equal(t, int64(5), stats.Requests)
+	assert.Equal(t, int64(4), stats.Delayed)
+	assert.Greater(t, stats.TotalWait, time.Duration(0))
+}
+
+func TestClient_WithRateLimit_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"site":{}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testAPIKey, WithRateLimit(0.1, 1))
+	_, err := client.GetSite()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.GetSiteContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,354 @@
+// Package replay provides an http.RoundTripper that records Ghost API
+// interactions to fixture files and replays them offline.
+//
+// In record mode requests are forwarded to the real site and each
+// request/response pair is captured. Authorization headers, cookies, the
+// Content API "key" query parameter and secret fields in JSON bodies are
+// scrubbed before anything is written to disk. In replay mode no network
+// access happens: each request is answered with the first unused recorded
+// interaction that has the same method, path and query.
+//
+//	rec, err := replay.New("testdata/publish.json", replay.ModeAuto)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//	client := libecto.NewClient(url, key, libecto.WithHTTPClient(rec.Client()))
+package replay
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the fixture file and never touches the network.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the real site and captures them.
+	// The fixture file is overwritten by Stop.
+	ModeRecord
+	// ModeAuto replays if the fixture file exists and records otherwise.
+	ModeAuto
+)
+
+// String returns the mode name.
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	case ModeAuto:
+		return "auto"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// ErrNoInteraction is returned in replay mode when no unused recorded
+// interaction matches a request.
+var ErrNoInteraction = errors.New("replay: no matching interaction")
+
+// Redacted replaces scrubbed values in fixtures.
+const Redacted = "[REDACTED]"
+
+// Cassette is the on-disk fixture format.
+type Cassette struct {
+	// Interactions are the recorded request/response pairs in order.
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the scrubbed request of an interaction.
+type RecordedRequest struct {
+	// Method is the HTTP method.
+	Method string `json:"method"`
+	// Path is the URL path, e.g. "/ghost/api/admin/posts/".
+	Path string `json:"path"`
+	// Query is the encoded query string, with secrets scrubbed.
+	Query string `json:"query,omitempty"`
+	// Header holds the request headers, with secrets removed.
+	Header http.Header `json:"header,omitempty"`
+	// Body is the request body; see BodyEncoding.
+	Body string `json:"body,omitempty"`
+	// BodyEncoding is "base64" for binary bodies and empty for text.
+	BodyEncoding string `json:"body_encoding,omitempty"`
+}
+
+// RecordedResponse is the scrubbed response of an interaction.
+type RecordedResponse struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int `json:"status_code"`
+	// Header holds the response headers, with secrets removed.
+	Header http.Header `json:"header,omitempty"`
+	// Body is the response body; see BodyEncoding.
+	Body string `json:"body,omitempty"`
+	// BodyEncoding is "base64" for binary bodies and empty for text.
+	BodyEncoding string `json:"body_encoding,omitempty"`
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to reach the real site in record mode.
+// The default is http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// WithScrubber adds a function that edits each interaction before it is
+// saved, after the built-in scrubbing. Use it to hide site-specific data.
+func WithScrubber(fn func(*Interaction)) Option {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, fn)
+	}
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+// It is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	scrubbers []func(*Interaction)
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New creates a Recorder backed by the fixture file at path.
+// In replay mode the file must exist; ModeAuto resolves to replay or record
+// depending on whether it does.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, transport: http.DefaultTransport}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeAuto {
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		} else {
+			r.mode = ModeRecord
+		}
+	}
+
+	if r.mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("replay: reading fixture: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("replay: parsing fixture %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns the effective mode, with ModeAuto resolved.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an *http.Client that uses the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns a copy of the recorded or loaded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Stop writes the fixture file in record mode. It is a no-op in replay mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("replay: creating fixture dir: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("replay: writing fixture: %w", err)
+	}
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// record forwards req and captures the scrubbed interaction.
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	in := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  scrubQuery(req.URL.RawQuery),
+			Header: scrubHeader(req.Header),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+		},
+	}
+	in.Request.Body, in.Request.BodyEncoding = encodeBody(req.Header.Get("Content-Type"), reqBody)
+	in.Response.Body, in.Response.BodyEncoding = encodeBody(resp.Header.Get("Content-Type"), respBody)
+	for _, scrub := range r.scrubbers {
+		scrub(&in)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers req from the first unused matching interaction.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	query := scrubQuery(req.URL.RawQuery)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !matches(in.Request, req.Method, req.URL.Path, query) {
+			continue
+		}
+		r.used[i] = true
+
+		body, err := decodeBody(in.Response.Body, in.Response.BodyEncoding)
+		if err != nil {
+			return nil, err
+		}
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, pathWithQuery(req.URL.Path, query))
+}
+
+// matches reports whether a recorded request has the given method, path and query.
+// Queries are compared as parsed values so parameter order does not matter.
+func matches(rec RecordedRequest, method, path, query string) bool {
+	if rec.Method != method || rec.Path != path {
+		return false
+	}
+	a, errA := url.ParseQuery(rec.Query)
+	b, errB := url.ParseQuery(query)
+	if errA != nil || errB != nil {
+		return rec.Query == query
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for k, va := range a {
+		vb, ok := b[k]
+		if !ok || strings.Join(va, "\x00") != strings.Join(vb, "\x00") {
+			return false
+		}
+	}
+	return true
+}
+
+func pathWithQuery(path, query string) string {
+	if query == "" {
+		return path
+	}
+	return path + "?" + query
+}
+
+// encodeBody stores text bodies as-is and everything else as base64.
+// JSON bodies have their secret fields scrubbed.
+func encodeBody(contentType string, body []byte) (string, string) {
+	if len(body) == 0 {
+		return "", ""
+	}
+	if strings.Contains(contentType, "json") {
+		body = scrubJSON(body)
+	}
+	if utf8.Valid(body) && !strings.HasPrefix(contentType, "multipart/") {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
@@ -0,0 +1,228 @@
+package replay
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visionik/libecto"
+)
+
+const testAPIKey = "test123:7365637265746b6579313233"
+
+func newGhostServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "ghost-admin-api-session=abc")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/ghost/api/admin/posts/":
+			w.Write([]byte(`{"posts":[{"id":"p1","title":"Hello <b>world</b>","status":"` + r.URL.Query().Get("filter") + `"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/ghost/api/admin/webhooks/":
+			w.Write([]byte(`{"webhooks":[{"id":"w1","event":"post.published","secret":"hunter2"}]}`))
+		case r.URL.Path == "/ghost/api/admin/settings/":
+			w.Write([]byte(`{"settings":[{"key":"title","value":"Blog"},{"key":"members_stripe_secret_key","value":"sk_live_123"}]}`))
+		case r.URL.Path == "/ghost/api/content/tags/":
+			w.Write([]byte(`{"tags":[{"slug":"news"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"message":"Resource not found","type":"NotFoundError"}]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "testdata", "session.json")
+	server := newGhostServer(t)
+
+	rec, err := New(fixture, ModeRecord)
+	require.NoError(t, err)
+	assert.Equal(t, ModeRecord, rec.Mode())
+
+	client := libecto.NewClient(server.URL, testAPIKey, libecto.WithHTTPClient(rec.Client()))
+	posts, err := client.ListPosts("published", 5)
+	require.NoError(t, err)
+	_, err = client.CreateWebhook(&libecto.Webhook{Event: "post.published", TargetURL: "https://example.com/hook"})
+	require.NoError(t, err)
+	_, err = client.GetSite()
+	require.Error(t, err)
+	require.NoError(t, rec.Stop())
+
+	// Replay with the server gone.
+	server.Close()
+	rep, err := New(fixture, ModeReplay)
+	require.NoError(t, err)
+	client = libecto.NewClient(server.URL, testAPIKey, libecto.WithHTTPClient(rep.Client()))
+
+	replayed, err := client.ListPosts("published", 5)
+	require.NoError(t, err)
+	assert.Equal(t, posts, replayed)
+	assert.Equal(t, "Hello <b>world</b>", replayed.Posts[0].Title)
+
+	hook, err := client.CreateWebhook(&libecto.Webhook{Event: "post.published"})
+	require.NoError(t, err)
+	assert.Equal(t, "w1", hook.ID)
+	assert.Equal(t, Redacted, hook.Secret)
+
+	_, err = client.GetSite()
+	assert.True(t, libecto.IsNotFound(err))
+}
+
+func TestRecord_Scrubs(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "scrub.json")
+	server := newGhostServer(t)
+
+	rec, err := New(fixture, ModeRecord)
+	require.NoError(t, err)
+
+	admin := libecto.NewClient(server.URL, testAPIKey, libecto.WithHTTPClient(rec.Client()))
+	_, err = admin.GetSettings()
+	require.NoError(t, err)
+	_, err = admin.CreateWebhook(&libecto.Webhook{Event: "post.published", Secret: "client-secret"})
+	require.NoError(t, err)
+
+	content := libecto.NewContentClient(server.URL, "contentkey123", libecto.WithHTTPClient(rec.Client()))
+	_, err = content.ListTags(nil)
+	require.NoError(t, err)
+	require.NoError(t, rec.Stop())
+
+	data, err := os.ReadFile(fixture)
+	require.NoError(t, err)
+	saved := string(data)
+	assert.NotContains(t, saved, "Authorization")
+	assert.NotContains(t, saved, "Set-Cookie")
+	assert.NotContains(t, saved, "hunter2")
+	assert.NotContains(t, saved, "client-secret")
+	assert.NotContains(t, saved, "sk_live_123")
+	assert.NotContains(t, saved, "contentkey123")
+	assert.Contains(t, saved, `\"value\":\"Blog\"`)
+
+	// The Content API client still matches with a different key.
+	rep, err := New(fixture, ModeReplay)
+	require.NoError(t, err)
+	content = libecto.NewContentClient(server.URL, "otherkey", libecto.WithHTTPClient(rep.Client()))
+	tags, err := content.ListTags(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "news", tags.Tags[0].Slug)
+}
+
+func TestReplay_MatchesMethodPathAndQuery(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "match.json")
+	server := newGhostServer(t)
+
+	rec, err := New(fixture, ModeRecord)
+	require.NoError(t, err)
+	client := libecto.NewClient(server.URL, testAPIKey, libecto.WithHTTPClient(rec.Client()))
+	_, err = client.ListPosts("draft", 0)
+	require.NoError(t, err)
+	_, err = client.ListPosts("published", 0)
+	require.NoError(t, err)
+	require.NoError(t, rec.Stop())
+	require.Len(t, rec.Interactions(), 2)
+
+	rep, err := New(fixture, ModeReplay)
+	require.NoError(t, err)
+	client = libecto.NewClient(server.URL, testAPIKey, libecto.WithHTTPClient(rep.Client()))
+
+	resp, err := client.ListPosts("published", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "status:published", resp.Posts[0].Status)
+	resp, err = client.ListPosts("draft", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "status:draft", resp.Posts[0].Status)
+
+	// Each interaction is used once.
+	_, err = client.ListPosts("draft", 0)
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+	_, err = client.ListPosts("scheduled", 0)
+	assert.ErrorIs(t, err, ErrNoInteraction)
+}
+
+func TestReplay_QueryOrderIgnored(t *testing.T) {
+	assert.True(t, matches(RecordedRequest{Method: "GET", Path: "/p/", Query: "a=1&b=2"}, "GET", "/p/", "b=2&a=1"))
+	assert.False(t, matches(RecordedRequest{Method: "GET", Path: "/p/", Query: "a=1"}, "GET", "/p/", "a=1&b=2"))
+	assert.False(t, matches(RecordedRequest{Method: "GET", Path: "/p/"}, "PUT", "/p/", ""))
+}
+
+func TestReplay_BinaryBodies(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "upload.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte{0x89, 'P', 'N', 'G', 0xff, 0x00})
+	}))
+	defer server.Close()
+
+	rec, err := New(fixture, ModeRecord)
+	require.NoError(t, err)
+	resp, err := rec.Client().Post(server.URL+"/upload", "application/octet-stream", strings.NewReader("\xff\xfe"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.NoError(t, rec.Stop())
+
+	in := rec.Interactions()[0]
+	assert.Equal(t, "base64", in.Request.BodyEncoding)
+	assert.Equal(t, "base64", in.Response.BodyEncoding)
+
+	rep, err := New(fixture, ModeReplay)
+	require.NoError(t, err)
+	resp, err = rep.Client().Post(server.URL+"/upload", "application/octet-stream", strings.NewReader("other"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G', 0xff, 0x00}, body)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+}
+
+func TestNew_Modes(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "auto.json")
+
+	_, err := New(fixture, ModeReplay)
+	require.Error(t, err)
+
+	rec, err := New(fixture, ModeAuto)
+	require.NoError(t, err)
+	assert.Equal(t, ModeRecord, rec.Mode())
+	require.NoError(t, rec.Stop())
+
+	rec, err = New(fixture, ModeAuto)
+	require.NoError(t, err)
+	assert.Equal(t, ModeReplay, rec.Mode())
+	require.NoError(t, rec.Stop())
+
+	require.NoError(t, os.WriteFile(fixture, []byte("{"), 0o644))
+	_, err = New(fixture, ModeReplay)
+	require.Error(t, err)
+
+	assert.Equal(t, "auto", ModeAuto.String())
+	assert.Equal(t, "Mode(9)", Mode(9).String())
+}
+
+func TestWithScrubber(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "custom.json")
+	server := newGhostServer(t)
+
+	rec, err := New(fixture, ModeRecord, WithScrubber(func(in *Interaction) {
+		in.Response.Body = strings.ReplaceAll(in.Response.Body, "Blog", "Example")
+	}), WithTransport(http.DefaultTransport))
+	require.NoError(t, err)
+	client := libecto.NewClient(server.URL, testAPIKey, libecto.WithHTTPClient(rec.Client()))
+	resp, err := client.GetSettings()
+	require.NoError(t, err)
+	assert.Equal(t, "Blog", resp.Settings[0].Value, "live response is not modified")
+	require.NoError(t, rec.Stop())
+
+	rep, err := New(fixture, ModeReplay)
+	require.NoError(t, err)
+	client = libecto.NewClient(server.URL, testAPIKey, libecto.WithHTTPClient(rep.Client()))
+	resp, err = client.GetSettings()
+	require.NoError(t, err)
+	assert.Equal(t, "Example", resp.Settings[0].Value)
+}
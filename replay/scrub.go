@@ -0,0 +1,132 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// sensitiveHeaders are removed from recorded requests and responses.
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+	"X-Api-Key":     true,
+}
+
+// sensitiveFields are JSON fields whose values are redacted.
+var sensitiveFields = map[string]bool{
+	"secret":        true,
+	"password":      true,
+	"token":         true,
+	"api_key":       true,
+	"admin_api_key": true,
+	"authorization": true,
+}
+
+// sensitiveParams are query parameters whose values are redacted.
+// "key" carries the Content API key.
+var sensitiveParams = map[string]bool{
+	"key":   true,
+	"token": true,
+}
+
+// sensitiveSettingWords mark settings entries ({"key": ..., "value": ...})
+// whose value is redacted, such as "members_stripe_secret_key".
+var sensitiveSettingWords = []string{"secret", "token", "password", "api_key"}
+
+// scrubHeader returns a copy of h without sensitive headers.
+func scrubHeader(h http.Header) http.Header {
+	out := http.Header{}
+	for name, values := range h {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			continue
+		}
+		out[name] = append([]string(nil), values...)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// scrubQuery redacts sensitive query parameters such as the Content API key.
+func scrubQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	changed := false
+	for name := range values {
+		if sensitiveParams[strings.ToLower(name)] {
+			values[name] = []string{Redacted}
+			changed = true
+		}
+	}
+	if !changed {
+		return rawQuery
+	}
+	return values.Encode()
+}
+
+// scrubJSON redacts sensitive fields at any depth of a JSON document.
+// Invalid JSON is returned unchanged.
+func scrubJSON(body []byte) []byte {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(scrubValue(v)); err != nil {
+		return body
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n"))
+}
+
+func scrubValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if isSensitiveSetting(t) {
+			t["value"] = Redacted
+		}
+		for k, child := range t {
+			if sensitiveFields[strings.ToLower(k)] {
+				t[k] = Redacted
+			} else {
+				t[k] = scrubValue(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = scrubValue(child)
+		}
+	}
+	return v
+}
+
+// isSensitiveSetting reports whether m is a settings entry holding a secret.
+func isSensitiveSetting(m map[string]interface{}) bool {
+	key, ok := m["key"].(string)
+	if !ok {
+		return false
+	}
+	if _, ok := m["value"]; !ok {
+		return false
+	}
+	key = strings.ToLower(key)
+	for _, word := range sensitiveSettingWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
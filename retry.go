@@ -0,0 +1,180 @@
+package libecto
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of failed API requests.
+// Requests are retried on network errors and on 429, 502, 503 and 504 responses.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values of 1 or less disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles on each subsequent retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, including delays asked for
+	// by a Retry-After header. Zero leaves delays uncapped.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent allows retrying POST requests, which may create duplicates
+	// if the first attempt reached Ghost but the response was lost.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for batch jobs against Ghost(Pro):
+// up to 4 attempts with exponential backoff between 500ms and 30s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+	}
+}
+
+// WithRetryPolicy enables automatic retries using the given policy.
+// A Retry-After header on the response takes precedence over the computed
+// backoff, up to the policy's MaxBackoff.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// allows reports whether the policy permits retrying a request with the given method.
+func (p RetryPolicy) allows(method string) bool {
+	if p.MaxAttempts <= 1 {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// backoff returns the delay before retry number attempt (1-indexed),
+// using exponential backoff with equal jitter.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryableStatus reports whether a response status code is worth retrying.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(resp *Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sendWithRetry sends a request, retrying according to the client's RetryPolicy.
+// The body is re-sent from the start on every attempt, and request asks the
+// TokenSource for a token each time so retries after token expiry still authenticate.
+// Streamed uploads are only retried when their content can be rewound.
+func (c *Client) sendWithRetry(ctx context.Context, method, path string, header http.Header, body *payload) (*Response, error) {
+	policy := c.retry
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		resp, err := c.attempt(ctx, method, path, header, body)
+
+		retry := attempt < policy.MaxAttempts && policy.allows(method) && body.replayable()
+		if err != nil {
+			retry = retry && ctx.Err() == nil && !errors.Is(err, errToken) && !errors.Is(err, ErrUploadTooLarge)
+		} else {
+			retry = retry && retryableStatus(resp.StatusCode)
+		}
+
+		var delay time.Duration
+		if retry {
+			delay = policy.backoff(attempt)
+			if d, ok := retryAfter(resp, time.Now()); ok {
+				delay = d
+				if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+					delay = policy.MaxBackoff
+				}
+			}
+		}
+		c.logAttempt(ctx, method, path, attempt, time.Since(start), resp, err, retry, delay)
+		c.logBodies(ctx, method, path, body.contentType, body.data, resp)
+
+		if !retry {
+			return resp, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt performs a single HTTP round trip and reads the full response.
+// An error that aborted an upload stream takes precedence over the
+// transport error it caused.
+func (c *Client) attempt(ctx context.Context, method, path string, header http.Header, body *payload) (*Response, error) {
+	bodyReader, contentType, err := body.open()
+	if err != nil {
+		return nil, err
+	}
+	defer body.close()
+
+	resp, err := c.request(ctx, method, path, header, contentType, bodyReader)
+	if err != nil {
+		if failure := body.failure(); failure != nil {
+			return nil, failure
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody}, nil
+}
@@ -0,0 +1,242 @@
+package libecto
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func newRetryTestServer(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) (*httptest.Server, *Client) {
+	server := httptest.NewServer(handler)
+	client := NewClient(server.URL, testAPIKey, opts...)
+	return server, client
+}
+
+func TestRetryPolicy_Allows(t *testing.T) {
+	p := DefaultRetryPolicy()
+	assert.True(t, p.allows("GET"))
+	assert.True(t, p.allows("PUT"))
+	assert.True(t, p.allows("DELETE"))
+	assert.False(t, p.allows("POST"))
+
+	p.RetryNonIdempotent = true
+	assert.True(t, p.allows("POST"))
+
+	assert.False(t, RetryPolicy{}.allows("GET"))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, max := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		d := p.backoff(attempt)
+		assert.GreaterOrEqual(t, d, max/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, d, max, "attempt %d", attempt)
+	}
+	assert.Equal(t, time.Duration(0), RetryPolicy{}.backoff(1))
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"missing", "", 0, false},
+		{"seconds", "3", 3 * time.Second, true},
+		{"negative", "-1", 0, false},
+		{"http date", now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second, true},
+		{"past date", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"garbage", "soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			got, ok := retryAfter(resp, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	_, ok := retryAfter(nil, now)
+	assert.False(t, ok)
+}
+
+func TestClient_Retry_ServiceUnavailable(t *testing.T) {
+	var calls int32
+	var tokens []string
+	server, client := newRetryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{{ID: "1"}}})
+	}, WithRetryPolicy(fastRetryPolicy()))
+	defer server.Close()
+
+	resp, err := client.ListPosts("", 0)
+	require.NoError(t, err)
+	assert.Len(t, resp.Posts, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	for _, token := range tokens {
+		assert.True(t, strings.HasPrefix(token, "Ghost "))
+	}
+}
+
+func TestClient_Retry_GivesUp(t *testing.T) {
+	var calls int32
+	server, client := newRetryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, WithRetryPolicy(fastRetryPolicy()))
+	defer server.Close()
+
+	_, err := client.ListPosts("", 0)
+	require.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	var respErr *ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusBadGateway, respErr.StatusCode)
+}
+
+func TestClient_Retry_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	var first time.Time
+	var elapsed time.Duration
+	server, client := newRetryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		elapsed = time.Since(first)
+		json.NewEncoder(w).Encode(TagsResponse{Tags: []Tag{}})
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}))
+	defer server.Close()
+
+	_, err := client.ListTags(0)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, elapsed, 900*time.Millisecond)
+}
+
+func TestClient_Retry_CapsRetryAfter(t *testing.T) {
+	var calls int32
+	server, client := newRetryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(TagsResponse{Tags: []Tag{}})
+	}, WithRetryPolicy(fastRetryPolicy()))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := client.ListTagsWithOptions(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestClient_Retry_SkipsNonIdempotent(t *testing.T) {
+	var calls int32
+	server, client := newRetryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetryPolicy(fastRetryPolicy()))
+	defer server.Close()
+
+	_, err := client.CreatePost(&Post{Title: "Once"})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClient_Retry_NonIdempotentResendsBody(t *testing.T) {
+	var calls int32
+	server, client := newRetryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"title":"Twice"`)
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(PostsResponse{Posts: []Post{{ID: "1", Title: "Twice"}}})
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, RetryNonIdempotent: true}))
+	defer server.Close()
+
+	post, err := client.CreatePost(&Post{Title: "Twice"})
+	require.NoError(t, err)
+	assert.Equal(t, "1", post.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestClient_Retry_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server, client := newRetryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}, WithRetryPolicy(fastRetryPolicy()))
+	defer server.Close()
+
+	err := client.DeletePost("missing")
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClient_Retry_ContextCanceledDuringBackoff(t *testing.T) {
+	server, client := newRetryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ListPostsContext(ctx, "", 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_Retry_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := NewClient(url, testAPIKey, WithRetryPolicy(fastRetryPolicy()))
+	_, err := client.GetSite()
+	require.Error(t, err)
+}
+
+func TestClient_Retry_InvalidKeyNotRetried(t *testing.T) {
+	client := NewClient("http://localhost", "invalid-key", WithRetryPolicy(fastRetryPolicy()))
+	_, err := client.ListPosts("", 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "generating token")
+}
@@ -1,8 +1,17 @@
 package libecto
 
+import "encoding/json"
+
 // Post represents a Ghost blog post with all standard fields.
 // Posts are the primary content type in Ghost and support various statuses,
 // visibility settings, and associations with tags and authors.
+//
+// Read-only fields such as URL, ReadingTime, PrimaryTag and Email are left
+// out of create and update requests, and GetPost returns the post's Lexical
+// or Mobiledoc content along with its HTML, so a post returned by GetPost
+// can be modified and passed to UpdatePost as is. Posts from list calls
+// carry only HTML unless ListOptions.Formats asks for more; updating one of
+// those has Ghost rebuild the content from its HTML.
 type Post struct {
 	// ID is the unique identifier for the post.
 	ID string `json:"id,omitempty"`
@@ -14,30 +23,149 @@ type Post struct {
 	Slug string `json:"slug,omitempty"`
 	// HTML is the rendered HTML content of the post.
 	HTML string `json:"html,omitempty"`
+	// Lexical is the post content as a serialized Lexical document,
+	// the editor format used by Ghost 5 and later.
+	Lexical string `json:"lexical,omitempty"`
 	// Mobiledoc is the internal document format used by Ghost.
 	Mobiledoc string `json:"mobiledoc,omitempty"`
-	// Status indicates the publication state: draft, published, or scheduled.
+	// Plaintext is the content as plain text, returned when requested with FormatPlaintext.
+	Plaintext string `json:"plaintext,omitempty"`
+	// CommentID identifies the post in comment systems; it defaults to the ID.
+	CommentID string `json:"comment_id,omitempty"`
+	// Status indicates the publication state: draft, published, scheduled or sent.
 	Status string `json:"status,omitempty"`
 	// Visibility controls who can see the post: public, members, paid, or tiers.
 	Visibility string `json:"visibility,omitempty"`
+	// Tiers are the tiers with access when Visibility is "tiers".
+	Tiers []Tier `json:"tiers,omitempty"`
 	// PublishedAt is the publication timestamp in ISO8601 format.
 	PublishedAt string `json:"published_at,omitempty"`
 	// CreatedAt is the creation timestamp.
 	CreatedAt string `json:"created_at,omitempty"`
 	// UpdatedAt is the last modification timestamp.
 	UpdatedAt string `json:"updated_at,omitempty"`
-	// Excerpt is an auto-generated summary of the post.
+	// URL is the public URL of the post. It is read-only.
+	URL string `json:"url,omitempty"`
+	// Excerpt is an auto-generated summary of the post. It is read-only.
 	Excerpt string `json:"excerpt,omitempty"`
 	// CustomExcerpt is a manually set summary.
 	CustomExcerpt string `json:"custom_excerpt,omitempty"`
+	// ReadingTime is the estimated reading time in minutes. It is read-only.
+	ReadingTime int `json:"reading_time,omitempty"`
 	// FeatureImage is the URL of the post's featured image.
 	FeatureImage string `json:"feature_image,omitempty"`
+	// FeatureImageAlt is the alt text of the featured image.
+	FeatureImageAlt string `json:"feature_image_alt,omitempty"`
+	// FeatureImageCaption is the caption of the featured image, as HTML.
+	FeatureImageCaption string `json:"feature_image_caption,omitempty"`
 	// Featured indicates whether this is a featured/pinned post.
 	Featured bool `json:"featured,omitempty"`
+	// ShowTitleAndFeatureImage controls whether the theme renders the title
+	// and featured image. Nil leaves Ghost's setting unchanged.
+	ShowTitleAndFeatureImage *bool `json:"show_title_and_feature_image,omitempty"`
+	// CustomTemplate is the theme template used to render the post.
+	CustomTemplate string `json:"custom_template,omitempty"`
+	// CanonicalURL overrides the canonical link of the post.
+	CanonicalURL string `json:"canonical_url,omitempty"`
+	// CodeinjectionHead is injected into the page head.
+	CodeinjectionHead string `json:"codeinjection_head,omitempty"`
+	// CodeinjectionFoot is injected before the closing body tag.
+	CodeinjectionFoot string `json:"codeinjection_foot,omitempty"`
+	// MetaTitle overrides the title used by search engines.
+	MetaTitle string `json:"meta_title,omitempty"`
+	// MetaDescription overrides the description used by search engines.
+	MetaDescription string `json:"meta_description,omitempty"`
+	// OGImage is the image used for Open Graph (Facebook) cards.
+	OGImage string `json:"og_image,omitempty"`
+	// OGTitle is the title used for Open Graph cards.
+	OGTitle string `json:"og_title,omitempty"`
+	// OGDescription is the description used for Open Graph cards.
+	OGDescription string `json:"og_description,omitempty"`
+	// TwitterImage is the image used for Twitter cards.
+	TwitterImage string `json:"twitter_image,omitempty"`
+	// TwitterTitle is the title used for Twitter cards.
+	TwitterTitle string `json:"twitter_title,omitempty"`
+	// TwitterDescription is the description used for Twitter cards.
+	TwitterDescription string `json:"twitter_description,omitempty"`
 	// Tags is the list of tags associated with the post.
 	Tags []Tag `json:"tags,omitempty"`
+	// PrimaryTag is the first public tag. It is read-only; reorder Tags to change it.
+	PrimaryTag *Tag `json:"primary_tag,omitempty"`
 	// Authors is the list of authors for the post.
 	Authors []Author `json:"authors,omitempty"`
+	// PrimaryAuthor is the first author. It is read-only; reorder Authors to change it.
+	PrimaryAuthor *Author `json:"primary_author,omitempty"`
+	// EmailSubject overrides the subject of the newsletter email.
+	EmailSubject string `json:"email_subject,omitempty"`
+	// EmailSegment is the member filter the post was emailed to (e.g., "all", "status:free").
+	EmailSegment string `json:"email_segment,omitempty"`
+	// EmailOnly indicates the post was sent as an email without being published on the site.
+	EmailOnly bool `json:"email_only,omitempty"`
+	// Newsletter is the newsletter the post was sent with. It is read-only.
+	Newsletter *Newsletter `json:"newsletter,omitempty"`
+	// Email describes the email sent for the post, if any. It is read-only.
+	Email *Email `json:"email,omitempty"`
+	// Frontmatter is extra data made available to the theme.
+	Frontmatter string `json:"frontmatter,omitempty"`
+}
+
+// Email is the newsletter email sent for a post.
+type Email struct {
+	// ID is the unique identifier.
+	ID string `json:"id,omitempty"`
+	// UUID is the universally unique identifier.
+	UUID string `json:"uuid,omitempty"`
+	// Status is the delivery state: pending, submitting, submitted or failed.
+	Status string `json:"status,omitempty"`
+	// RecipientFilter is the member filter the email was sent to.
+	RecipientFilter string `json:"recipient_filter,omitempty"`
+	// Error describes why sending failed.
+	Error string `json:"error,omitempty"`
+	// EmailCount is the number of recipients.
+	EmailCount int `json:"email_count,omitempty"`
+	// DeliveredCount is the number of delivered emails.
+	DeliveredCount int `json:"delivered_count,omitempty"`
+	// OpenedCount is the number of opened emails.
+	OpenedCount int `json:"opened_count,omitempty"`
+	// FailedCount is the number of failed deliveries.
+	FailedCount int `json:"failed_count,omitempty"`
+	// Subject is the email subject.
+	Subject string `json:"subject,omitempty"`
+	// From is the sender address.
+	From string `json:"from,omitempty"`
+	// ReplyTo is the reply-to address.
+	ReplyTo string `json:"reply_to,omitempty"`
+	// HTML is the rendered email body.
+	HTML string `json:"html,omitempty"`
+	// Plaintext is the plain-text email body.
+	Plaintext string `json:"plaintext,omitempty"`
+	// TrackOpens indicates whether opens are tracked.
+	TrackOpens bool `json:"track_opens,omitempty"`
+	// TrackClicks indicates whether link clicks are tracked.
+	TrackClicks bool `json:"track_clicks,omitempty"`
+	// NewsletterID is the newsletter the email was sent with.
+	NewsletterID string `json:"newsletter_id,omitempty"`
+	// SubmittedAt is when the email was handed to the email provider.
+	SubmittedAt string `json:"submitted_at,omitempty"`
+	// CreatedAt is the creation timestamp.
+	CreatedAt string `json:"created_at,omitempty"`
+	// UpdatedAt is the last modification timestamp.
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// EmailsResponse is the API response structure for emails.
+type EmailsResponse struct {
+	// Emails is the array of returned emails.
+	Emails []Email `json:"emails"`
+}
+
+// writable returns a copy of p without the read-only fields, for sending
+// in create and update requests.
+func (p Post) writable() Post {
+	p.URL, p.Excerpt, p.ReadingTime = "", "", 0
+	p.PrimaryTag, p.PrimaryAuthor = nil, nil
+	p.Newsletter, p.Email = nil, nil
+	return p
 }
 
 // PostsResponse is the API response structure for post listings.
@@ -52,6 +180,7 @@ type PostsResponse struct {
 // Page represents a Ghost page, which is a static content type.
 // Pages have similar fields to posts but are typically used for
 // non-chronological content like About or Contact pages.
+// As with Post, read-only fields are left out of create and update requests.
 type Page struct {
 	// ID is the unique identifier for the page.
 	ID string `json:"id,omitempty"`
@@ -63,24 +192,87 @@ type Page struct {
 	Slug string `json:"slug,omitempty"`
 	// HTML is the rendered HTML content.
 	HTML string `json:"html,omitempty"`
+	// Lexical is the page content as a serialized Lexical document.
+	Lexical string `json:"lexical,omitempty"`
 	// Mobiledoc is the internal document format.
 	Mobiledoc string `json:"mobiledoc,omitempty"`
+	// Plaintext is the content as plain text, returned when requested with FormatPlaintext.
+	Plaintext string `json:"plaintext,omitempty"`
+	// CommentID identifies the page in comment systems; it defaults to the ID.
+	CommentID string `json:"comment_id,omitempty"`
 	// Status indicates the publication state.
 	Status string `json:"status,omitempty"`
 	// Visibility controls who can see the page.
 	Visibility string `json:"visibility,omitempty"`
+	// Tiers are the tiers with access when Visibility is "tiers".
+	Tiers []Tier `json:"tiers,omitempty"`
 	// PublishedAt is the publication timestamp.
 	PublishedAt string `json:"published_at,omitempty"`
 	// CreatedAt is the creation timestamp.
 	CreatedAt string `json:"created_at,omitempty"`
 	// UpdatedAt is the last modification timestamp.
 	UpdatedAt string `json:"updated_at,omitempty"`
+	// URL is the public URL of the page. It is read-only.
+	URL string `json:"url,omitempty"`
+	// Excerpt is an auto-generated summary of the page. It is read-only.
+	Excerpt string `json:"excerpt,omitempty"`
+	// CustomExcerpt is a manually set summary.
+	CustomExcerpt string `json:"custom_excerpt,omitempty"`
+	// ReadingTime is the estimated reading time in minutes. It is read-only.
+	ReadingTime int `json:"reading_time,omitempty"`
 	// FeatureImage is the URL of the featured image.
 	FeatureImage string `json:"feature_image,omitempty"`
+	// FeatureImageAlt is the alt text of the featured image.
+	FeatureImageAlt string `json:"feature_image_alt,omitempty"`
+	// FeatureImageCaption is the caption of the featured image, as HTML.
+	FeatureImageCaption string `json:"feature_image_caption,omitempty"`
+	// Featured indicates whether this is a featured page.
+	Featured bool `json:"featured,omitempty"`
+	// ShowTitleAndFeatureImage controls whether the theme renders the title
+	// and featured image. Nil leaves Ghost's setting unchanged.
+	ShowTitleAndFeatureImage *bool `json:"show_title_and_feature_image,omitempty"`
+	// CustomTemplate is the theme template used to render the page.
+	CustomTemplate string `json:"custom_template,omitempty"`
+	// CanonicalURL overrides the canonical link of the page.
+	CanonicalURL string `json:"canonical_url,omitempty"`
+	// CodeinjectionHead is injected into the page head.
+	CodeinjectionHead string `json:"codeinjection_head,omitempty"`
+	// CodeinjectionFoot is injected before the closing body tag.
+	CodeinjectionFoot string `json:"codeinjection_foot,omitempty"`
+	// MetaTitle overrides the title used by search engines.
+	MetaTitle string `json:"meta_title,omitempty"`
+	// MetaDescription overrides the description used by search engines.
+	MetaDescription string `json:"meta_description,omitempty"`
+	// OGImage is the image used for Open Graph (Facebook) cards.
+	OGImage string `json:"og_image,omitempty"`
+	// OGTitle is the title used for Open Graph cards.
+	OGTitle string `json:"og_title,omitempty"`
+	// OGDescription is the description used for Open Graph cards.
+	OGDescription string `json:"og_description,omitempty"`
+	// TwitterImage is the image used for Twitter cards.
+	TwitterImage string `json:"twitter_image,omitempty"`
+	// TwitterTitle is the title used for Twitter cards.
+	TwitterTitle string `json:"twitter_title,omitempty"`
+	// TwitterDescription is the description used for Twitter cards.
+	TwitterDescription string `json:"twitter_description,omitempty"`
 	// Tags is the list of associated tags.
 	Tags []Tag `json:"tags,omitempty"`
+	// PrimaryTag is the first public tag. It is read-only.
+	PrimaryTag *Tag `json:"primary_tag,omitempty"`
 	// Authors is the list of authors.
 	Authors []Author `json:"authors,omitempty"`
+	// PrimaryAuthor is the first author. It is read-only.
+	PrimaryAuthor *Author `json:"primary_author,omitempty"`
+	// Frontmatter is extra data made available to the theme.
+	Frontmatter string `json:"frontmatter,omitempty"`
+}
+
+// writable returns a copy of p without the read-only fields, for sending
+// in create and update requests.
+func (p Page) writable() Page {
+	p.URL, p.Excerpt, p.ReadingTime = "", "", 0
+	p.PrimaryTag, p.PrimaryAuthor = nil, nil
+	return p
 }
 
 // PagesResponse is the API response structure for page listings.
@@ -151,6 +343,57 @@ type UsersResponse struct {
 	Meta *Meta `json:"meta,omitempty"`
 }
 
+// AuthorsResponse is the Content API response structure for author listings.
+type AuthorsResponse struct {
+	// Authors is the array of returned authors.
+	Authors []Author `json:"authors"`
+	// Meta contains pagination information.
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+// Tier represents a Ghost membership tier.
+// Tiers define the free and paid levels that content can be restricted to.
+type Tier struct {
+	// ID is the unique identifier.
+	ID string `json:"id,omitempty"`
+	// Name is the tier display name.
+	Name string `json:"name,omitempty"`
+	// Slug is the URL-friendly identifier.
+	Slug string `json:"slug,omitempty"`
+	// Description is shown to members choosing a tier.
+	Description string `json:"description,omitempty"`
+	// Active indicates whether the tier is available for signup.
+	Active bool `json:"active,omitempty"`
+	// Type is either "free" or "paid".
+	Type string `json:"type,omitempty"`
+	// Visibility controls whether the tier is shown in the portal: public or none.
+	Visibility string `json:"visibility,omitempty"`
+	// WelcomePageURL is where new members of the tier are redirected.
+	WelcomePageURL string `json:"welcome_page_url,omitempty"`
+	// MonthlyPrice is the monthly price in the currency's smallest unit.
+	MonthlyPrice *int `json:"monthly_price,omitempty"`
+	// YearlyPrice is the yearly price in the currency's smallest unit.
+	YearlyPrice *int `json:"yearly_price,omitempty"`
+	// Currency is the ISO 4217 currency code.
+	Currency string `json:"currency,omitempty"`
+	// Benefits lists the tier's benefits.
+	Benefits []string `json:"benefits,omitempty"`
+	// TrialDays is the length of the free trial.
+	TrialDays int `json:"trial_days,omitempty"`
+	// CreatedAt is the creation timestamp.
+	CreatedAt string `json:"created_at,omitempty"`
+	// UpdatedAt is the last modification timestamp.
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// TiersResponse is the API response structure for tier listings.
+type TiersResponse struct {
+	// Tiers is the array of returned tiers.
+	Tiers []Tier `json:"tiers"`
+	// Meta contains pagination information.
+	Meta *Meta `json:"meta,omitempty"`
+}
+
 // Site represents Ghost site configuration and metadata.
 // It contains general information about the Ghost installation.
 type Site struct {
@@ -189,6 +432,59 @@ type SettingsResponse struct {
 	Settings []Setting `json:"settings"`
 }
 
+// NavigationItem is a single entry in the site navigation.
+type NavigationItem struct {
+	// Label is the link text.
+	Label string `json:"label"`
+	// URL is the link target.
+	URL string `json:"url"`
+}
+
+// ContentSettings is the public site settings object returned by the Content API.
+// Unlike the Admin API, the Content API returns settings as a single object.
+type ContentSettings struct {
+	// Title is the site name.
+	Title string `json:"title"`
+	// Description is the site tagline or description.
+	Description string `json:"description"`
+	// Logo is the URL of the site logo.
+	Logo string `json:"logo"`
+	// Icon is the URL of the site favicon.
+	Icon string `json:"icon"`
+	// AccentColor is the brand color in hex format.
+	AccentColor string `json:"accent_color"`
+	// CoverImage is the URL of the site cover image.
+	CoverImage string `json:"cover_image"`
+	// Facebook is the site's Facebook page.
+	Facebook string `json:"facebook"`
+	// Twitter is the site's Twitter handle.
+	Twitter string `json:"twitter"`
+	// Locale is the site language (e.g., "en").
+	Locale string `json:"locale"`
+	// Timezone is the site timezone (e.g., "Etc/UTC").
+	Timezone string `json:"timezone"`
+	// CodeinjectionHead is custom code injected into the site header.
+	CodeinjectionHead string `json:"codeinjection_head"`
+	// CodeinjectionFoot is custom code injected into the site footer.
+	CodeinjectionFoot string `json:"codeinjection_foot"`
+	// Navigation is the primary navigation.
+	Navigation []NavigationItem `json:"navigation"`
+	// SecondaryNavigation is the secondary navigation.
+	SecondaryNavigation []NavigationItem `json:"secondary_navigation"`
+	// MetaTitle is the title used in search results.
+	MetaTitle string `json:"meta_title"`
+	// MetaDescription is the description used in search results.
+	MetaDescription string `json:"meta_description"`
+	// URL is the site's public URL.
+	URL string `json:"url"`
+}
+
+// ContentSettingsResponse is the Content API response structure for site settings.
+type ContentSettingsResponse struct {
+	// Settings contains the public site settings.
+	Settings ContentSettings `json:"settings"`
+}
+
 // Newsletter represents a Ghost newsletter configuration.
 // Newsletters are used for email distribution to subscribers.
 type Newsletter struct {
@@ -216,6 +512,8 @@ type Newsletter struct {
 type NewslettersResponse struct {
 	// Newsletters is the array of newsletters.
 	Newsletters []Newsletter `json:"newsletters"`
+	// Meta contains pagination information.
+	Meta *Meta `json:"meta,omitempty"`
 }
 
 // Webhook represents a Ghost webhook configuration.
@@ -243,6 +541,62 @@ type Webhook struct {
 type WebhooksResponse struct {
 	// Webhooks is the array of webhooks.
 	Webhooks []Webhook `json:"webhooks"`
+	// Meta contains pagination information when available.
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+// Member represents a subscriber of the Ghost site.
+type Member struct {
+	// ID is the unique identifier.
+	ID string `json:"id,omitempty"`
+	// UUID is the universally unique identifier used in unsubscribe links.
+	UUID string `json:"uuid,omitempty"`
+	// Email is the member's email address.
+	Email string `json:"email,omitempty"`
+	// Name is the member's name.
+	Name string `json:"name,omitempty"`
+	// Note is a private note visible to staff.
+	Note string `json:"note,omitempty"`
+	// Status is "free", "paid" or "comped".
+	Status string `json:"status,omitempty"`
+	// Subscribed indicates whether the member receives emails.
+	Subscribed bool `json:"subscribed,omitempty"`
+	// Labels are the labels assigned to the member.
+	Labels []Label `json:"labels,omitempty"`
+	// Newsletters are the newsletters the member is subscribed to.
+	Newsletters []Newsletter `json:"newsletters,omitempty"`
+	// AvatarImage is the URL of the member's Gravatar.
+	AvatarImage string `json:"avatar_image,omitempty"`
+	// EmailCount is the number of emails sent to the member.
+	EmailCount int `json:"email_count,omitempty"`
+	// EmailOpenedCount is the number of emails the member opened.
+	EmailOpenedCount int `json:"email_opened_count,omitempty"`
+	// EmailOpenRate is the open rate in percent, or nil if too few emails were sent.
+	EmailOpenRate *int `json:"email_open_rate,omitempty"`
+	// LastSeenAt is when the member was last active.
+	LastSeenAt string `json:"last_seen_at,omitempty"`
+	// CreatedAt is when the member signed up.
+	CreatedAt string `json:"created_at,omitempty"`
+	// UpdatedAt is the last modification timestamp.
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// Label is a tag used to organize members.
+type Label struct {
+	// ID is the unique identifier.
+	ID string `json:"id,omitempty"`
+	// Name is the label name.
+	Name string `json:"name,omitempty"`
+	// Slug is the URL-friendly version of the name.
+	Slug string `json:"slug,omitempty"`
+}
+
+// MembersResponse is the API response for member listings.
+type MembersResponse struct {
+	// Members is the array of members.
+	Members []Member `json:"members"`
+	// Meta contains pagination information.
+	Meta *Meta `json:"meta,omitempty"`
 }
 
 // Image represents an uploaded image with its URL.
@@ -269,7 +623,7 @@ type Meta struct {
 type Pagination struct {
 	// Page is the current page number (1-indexed).
 	Page int `json:"page"`
-	// Limit is the number of items per page.
+	// Limit is the number of items per page, or LimitAll when all items were requested.
 	Limit int `json:"limit"`
 	// Pages is the total number of pages.
 	Pages int `json:"pages"`
@@ -281,6 +635,40 @@ type Pagination struct {
 	Prev *int `json:"prev"`
 }
 
+// UnmarshalJSON decodes pagination, mapping a limit of "all" to LimitAll.
+func (p *Pagination) UnmarshalJSON(data []byte) error {
+	type pagination Pagination
+	aux := struct {
+		*pagination
+		Limit json.RawMessage `json:"limit"`
+	}{pagination: (*pagination)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	switch string(aux.Limit) {
+	case "", "null":
+		p.Limit = 0
+	case `"all"`:
+		p.Limit = LimitAll
+	default:
+		return json.Unmarshal(aux.Limit, &p.Limit)
+	}
+	return nil
+}
+
+// MarshalJSON encodes pagination, writing LimitAll as "all".
+func (p Pagination) MarshalJSON() ([]byte, error) {
+	type pagination Pagination
+	aux := struct {
+		pagination
+		Limit interface{} `json:"limit"`
+	}{pagination: pagination(p), Limit: p.Limit}
+	if p.Limit == LimitAll {
+		aux.Limit = "all"
+	}
+	return json.Marshal(aux)
+}
+
 // APIError represents a single error from the Ghost API.
 type APIError struct {
 	// Message is the human-readable error description.
@@ -147,6 +147,86 @@ func TestPost_JSONUnmarshal(t *testing.T) {
 	}
 }
 
+// ghostPost is a post as returned by the Admin API with every field populated.
+const ghostPost = `{
+	"id": "6567a1", "uuid": "2f2a-4b", "title": "Launch", "slug": "launch",
+	"html": "<p>Hi</p>", "lexical": "{\"root\":{}}", "plaintext": "Hi", "comment_id": "6567a1",
+	"status": "sent", "visibility": "tiers",
+	"tiers": [{"id": "t1", "name": "Gold", "type": "paid"}],
+	"published_at": "2024-05-01T10:00:00.000Z", "created_at": "2024-04-30T09:00:00.000Z", "updated_at": "2024-05-01T10:00:01.000Z",
+	"url": "https://example.com/launch/", "excerpt": "Hi", "custom_excerpt": "Launch day", "reading_time": 3,
+	"feature_image": "https://example.com/a.jpg", "feature_image_alt": "A rocket", "feature_image_caption": "<b>Liftoff</b>",
+	"featured": true, "show_title_and_feature_image": false, "custom_template": "custom-wide",
+	"canonical_url": "https://other.example/launch", "codeinjection_head": "<style></style>", "codeinjection_foot": "<script></script>",
+	"meta_title": "Meta", "meta_description": "Meta desc",
+	"og_image": "https://example.com/og.jpg", "og_title": "OG", "og_description": "OG desc",
+	"twitter_image": "https://example.com/tw.jpg", "twitter_title": "TW", "twitter_description": "TW desc",
+	"tags": [{"id": "g1", "name": "News", "slug": "news"}], "primary_tag": {"id": "g1", "name": "News", "slug": "news"},
+	"authors": [{"id": "u1", "name": "Jane", "slug": "jane"}], "primary_author": {"id": "u1", "name": "Jane", "slug": "jane"},
+	"email_subject": "Big news", "email_segment": "status:-free", "email_only": true,
+	"newsletter": {"id": "n1", "name": "Weekly", "description": "Weekly digest", "slug": "weekly", "status": "active"},
+	"email": {"id": "e1", "status": "submitted", "recipient_filter": "status:-free", "email_count": 120,
+		"delivered_count": 118, "opened_count": 60, "failed_count": 2, "subject": "Big news",
+		"track_opens": true, "newsletter_id": "n1", "submitted_at": "2024-05-01T10:00:05.000Z"},
+	"frontmatter": "layout: wide"
+}`
+
+func TestPost_FullResource(t *testing.T) {
+	var post Post
+	require.NoError(t, json.Unmarshal([]byte(ghostPost), &post))
+
+	assert.Equal(t, `{"root":{}}`, post.Lexical)
+	assert.Equal(t, "Gold", post.Tiers[0].Name)
+	assert.Equal(t, "https://example.com/launch/", post.URL)
+	assert.Equal(t, 3, post.ReadingTime)
+	assert.Equal(t, "A rocket", post.FeatureImageAlt)
+	require.NotNil(t, post.ShowTitleAndFeatureImage)
+	assert.False(t, *post.ShowTitleAndFeatureImage)
+	assert.Equal(t, "<script></script>", post.CodeinjectionFoot)
+	assert.Equal(t, "OG desc", post.OGDescription)
+	assert.Equal(t, "TW", post.TwitterTitle)
+	assert.Equal(t, "news", post.PrimaryTag.Slug)
+	assert.Equal(t, "jane", post.PrimaryAuthor.Slug)
+	assert.Equal(t, "status:-free", post.EmailSegment)
+	assert.Equal(t, "weekly", post.Newsletter.Slug)
+	require.NotNil(t, post.Email)
+	assert.Equal(t, 118, post.Email.DeliveredCount)
+	assert.Equal(t, "n1", post.Email.NewsletterID)
+
+	// Nothing Ghost returned is lost when the post is encoded again.
+	data, err := json.Marshal(post)
+	require.NoError(t, err)
+	assert.JSONEq(t, ghostPost, string(data))
+}
+
+func TestPage_FullResource(t *testing.T) {
+	var page Page
+	require.NoError(t, json.Unmarshal([]byte(ghostPost), &page))
+	assert.Equal(t, "custom-wide", page.CustomTemplate)
+	require.NotNil(t, page.ShowTitleAndFeatureImage)
+	assert.False(t, *page.ShowTitleAndFeatureImage)
+
+	data, err := json.Marshal(page)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	for _, key := range []string{"lexical", "og_title", "twitter_image", "meta_title", "canonical_url", "primary_tag", "tiers"} {
+		assert.Contains(t, fields, key)
+	}
+	assert.NotContains(t, fields, "email")
+}
+
+func TestPost_ShowTitleAndFeatureImageOmitted(t *testing.T) {
+	data, err := json.Marshal(Post{Title: "x"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "show_title_and_feature_image")
+
+	show := false
+	data, err = json.Marshal(Post{ShowTitleAndFeatureImage: &show})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"show_title_and_feature_image":false`)
+}
+
 func TestPostsResponse_JSONUnmarshal(t *testing.T) {
 	input := `{
 		"posts": [
@@ -395,6 +475,11 @@ func TestPagination_JSONUnmarshal(t *testing.T) {
 			input: `{"page": 1, "limit": 15, "pages": 1, "total": 5, "next": null, "prev": null}`,
 			want:  Pagination{Page: 1, Limit: 15, Pages: 1, Total: 5, Next: nil, Prev: nil},
 		},
+		{
+			name:  "limit all",
+			input: `{"page": 1, "limit": "all", "pages": 1, "total": 40, "next": null, "prev": null}`,
+			want:  Pagination{Page: 1, Limit: LimitAll, Pages: 1, Total: 40},
+		},
 	}
 
 	for _, tt := range tests {
@@ -422,6 +507,23 @@ func TestPagination_JSONUnmarshal(t *testing.T) {
 	}
 }
 
+func TestPagination_JSONRoundTrip(t *testing.T) {
+	for _, p := range []Pagination{{Page: 2, Limit: 15, Pages: 3, Total: 40, Next: intPtr(3), Prev: intPtr(1)}, {Page: 1, Limit: LimitAll, Pages: 1, Total: 40}} {
+		data, err := json.Marshal(p)
+		require.NoError(t, err)
+		var decoded Pagination
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, p, decoded)
+	}
+
+	data, err := json.Marshal(Pagination{Limit: LimitAll})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"limit":"all"`)
+
+	var p Pagination
+	require.Error(t, json.Unmarshal([]byte(`{"limit": "many"}`), &p))
+}
+
 func TestAPIError_JSONUnmarshal(t *testing.T) {
 	input := `{"message": "Resource not found", "context": "Post with id 123", "type": "NotFoundError"}`
 
@@ -0,0 +1,334 @@
+package libecto
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrUploadTooLarge is returned when an upload exceeds its MaxSize.
+var ErrUploadTooLarge = errors.New("upload exceeds maximum size")
+
+// FileUpload is the Request.Body of multipart file uploads. The content is
+// streamed to Ghost rather than buffered in memory.
+type FileUpload struct {
+	// Filename is the name sent in the Content-Disposition header.
+	Filename string
+	// Content is the file data. If it implements io.Seeker the upload can be
+	// retried; otherwise it is sent at most once.
+	Content io.Reader
+	// ContentType is the MIME type of the file. When empty it is derived from
+	// the file extension, falling back to sniffing the first 512 bytes.
+	ContentType string
+	// Size is the content length in bytes if known, or 0. It is reported as
+	// the total to Progress and checked against MaxSize before sending.
+	Size int64
+	// MaxSize rejects uploads larger than this many bytes with ErrUploadTooLarge.
+	// Zero means no limit.
+	MaxSize int64
+	// Progress, if set, is called as data is sent with the bytes sent so far
+	// and the total size, which is -1 when unknown.
+	Progress func(sent, total int64)
+}
+
+// UploadOptions customize a single upload.
+type UploadOptions struct {
+	// ContentType overrides content type detection.
+	ContentType string
+	// MaxSize overrides the client's maximum upload size. Zero uses the client default.
+	MaxSize int64
+	// Progress is called as data is sent with the bytes sent so far and the
+	// total size, which is -1 when unknown.
+	Progress func(sent, total int64)
+}
+
+// WithMaxUploadSize sets the default maximum size of uploaded files.
+// Larger uploads fail with ErrUploadTooLarge. Zero, the default, means no limit.
+func WithMaxUploadSize(n int64) ClientOption {
+	return func(c *Client) {
+		c.maxUploadSize = n
+	}
+}
+
+// UploadImageWithOptions uploads an image from r with per-upload options.
+// A nil opts behaves like UploadImageReaderContext.
+func (c *Client) UploadImageWithOptions(ctx context.Context, r io.Reader, filename string, opts *UploadOptions) (*ImagesResponse, error) {
+	upload := &FileUpload{Filename: filename, Content: r}
+	if opts != nil {
+		upload.ContentType = opts.ContentType
+		upload.MaxSize = opts.MaxSize
+		upload.Progress = opts.Progress
+	}
+	var result ImagesResponse
+	if err := c.upload(ctx, "/images/upload/", upload, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// uploadFile opens filePath and uploads it to path.
+func (c *Client) uploadFile(ctx context.Context, path, filePath string, result interface{}) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return c.upload(ctx, path, &FileUpload{Filename: filepath.Base(filePath), Content: file}, result)
+}
+
+// upload is the shared implementation of the upload endpoints. It fills in
+// the size and the client's size limit, then sends the file through the
+// middleware chain as a streamed multipart body.
+func (c *Client) upload(ctx context.Context, path string, upload *FileUpload, result interface{}) error {
+	if upload.Size == 0 {
+		upload.Size = contentSize(upload.Content)
+	}
+	if upload.MaxSize == 0 {
+		upload.MaxSize = c.maxUploadSize
+	}
+	if err := c.do(ctx, "POST", path, upload, result); err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+	return nil
+}
+
+// contentSize returns the number of bytes remaining in r, or 0 if unknown.
+func contentSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case interface{ Stat() (fs.FileInfo, error) }:
+		info, err := v.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0
+		}
+		size := info.Size()
+		if s, ok := r.(io.Seeker); ok {
+			if pos, err := s.Seek(0, io.SeekCurrent); err == nil {
+				size -= pos
+			}
+		}
+		return size
+	}
+	return 0
+}
+
+// payload is an encoded request body that can be opened once per attempt.
+type payload struct {
+	// data and contentType hold buffered JSON bodies.
+	data        []byte
+	contentType string
+	// stream is set for multipart uploads.
+	stream *uploadStream
+}
+
+// encodeBody encodes a Request.Body. JSON bodies are marshaled up front;
+// file uploads are prepared for streaming.
+func encodeBody(body interface{}) (*payload, error) {
+	switch b := body.(type) {
+	case nil:
+		return &payload{}, nil
+	case *FileUpload:
+		if b.MaxSize > 0 && b.Size > b.MaxSize {
+			return nil, fmt.Errorf("%w: %s is %d bytes, limit is %d", ErrUploadTooLarge, b.Filename, b.Size, b.MaxSize)
+		}
+		return &payload{stream: newUploadStream(b)}, nil
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+		return &payload{data: data, contentType: "application/json"}, nil
+	}
+}
+
+// open returns a reader for the body and its content type.
+// Every successful open must be followed by a call to close.
+func (p *payload) open() (io.Reader, string, error) {
+	if p.stream != nil {
+		return p.stream.open()
+	}
+	if p.data == nil {
+		return nil, "", nil
+	}
+	return bytes.NewReader(p.data), p.contentType, nil
+}
+
+// close releases the reader returned by open. For uploads it stops the
+// writing goroutine and waits for it, so the content is no longer being read.
+func (p *payload) close() {
+	if p.stream != nil {
+		p.stream.close()
+	}
+}
+
+// replayable reports whether the body can be sent again after an attempt.
+func (p *payload) replayable() bool {
+	return p.stream == nil || p.stream.seekable()
+}
+
+// failure returns the error that aborted the last upload stream, if any.
+func (p *payload) failure() error {
+	if p.stream == nil {
+		return nil
+	}
+	return p.stream.failure()
+}
+
+// uploadStream writes a FileUpload as multipart form data through a pipe.
+type uploadStream struct {
+	upload *FileUpload
+	start  int64 // offset of the content in a seekable reader, or -1
+	opened bool
+	pr     *io.PipeReader
+	done   chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+func newUploadStream(upload *FileUpload) *uploadStream {
+	s := &uploadStream{upload: upload, start: -1}
+	if seeker, ok := upload.Content.(io.Seeker); ok {
+		if pos, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			s.start = pos
+		}
+	}
+	return s
+}
+
+func (s *uploadStream) seekable() bool {
+	return s.start >= 0
+}
+
+func (s *uploadStream) failure() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *uploadStream) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// open starts streaming the upload and returns the reading end of the pipe.
+func (s *uploadStream) open() (io.Reader, string, error) {
+	u := s.upload
+	if s.opened {
+		if !s.seekable() {
+			return nil, "", fmt.Errorf("upload of %s cannot be resent", u.Filename)
+		}
+		if _, err := u.Content.(io.Seeker).Seek(s.start, io.SeekStart); err != nil {
+			return nil, "", err
+		}
+	}
+	s.opened = true
+	s.fail(nil)
+
+	content := bufio.NewReader(u.Content)
+	contentType := u.ContentType
+	if contentType == "" {
+		contentType = detectContentType(u.Filename, content)
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	s.pr, s.done = pr, make(chan struct{})
+	go func() {
+		defer close(s.done)
+		err := s.write(mw, content, contentType)
+		if err == nil {
+			err = mw.Close()
+		}
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			// Failures of the content itself, as opposed to the request
+			// closing the pipe, are reported instead of the transport error.
+			s.fail(err)
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, mw.FormDataContentType(), nil
+}
+
+func (s *uploadStream) close() {
+	if s.pr == nil {
+		return
+	}
+	s.pr.Close()
+	<-s.done
+	s.pr, s.done = nil, nil
+}
+
+// write copies the file part into mw, enforcing MaxSize and reporting progress.
+func (s *uploadStream) write(mw *multipart.Writer, content io.Reader, contentType string) error {
+	u := s.upload
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(u.Filename)))
+	h.Set("Content-Type", contentType)
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+
+	total := u.Size
+	if total <= 0 {
+		total = -1
+	}
+	var sent int64
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			sent += int64(n)
+			if u.MaxSize > 0 && sent > u.MaxSize {
+				return fmt.Errorf("%w: %s exceeds %d bytes", ErrUploadTooLarge, u.Filename, u.MaxSize)
+			}
+			if _, err := part.Write(buf[:n]); err != nil {
+				return err
+			}
+			if u.Progress != nil {
+				u.Progress(sent, total)
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+// detectContentType guesses the MIME type from the extension, then by sniffing.
+func detectContentType(filename string, content *bufio.Reader) string {
+	if t := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename))); t != "" {
+		if mediaType, _, err := mime.ParseMediaType(t); err == nil {
+			return mediaType
+		}
+	}
+	head, _ := content.Peek(512)
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	return mediaType
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,218 @@
+package libecto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readUpload parses the multipart upload in r and returns the file part.
+func readUpload(t *testing.T, r *http.Request) (filename, contentType string, data []byte) {
+	t.Helper()
+	reader, err := r.MultipartReader()
+	require.NoError(t, err)
+	part, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "file", part.FormName())
+	data, err = io.ReadAll(part)
+	require.NoError(t, err)
+	return part.FileName(), part.Header.Get("Content-Type"), data
+}
+
+func TestEncodeBody(t *testing.T) {
+	body, err := encodeBody(nil)
+	require.NoError(t, err)
+	r, contentType, err := body.open()
+	require.NoError(t, err)
+	assert.Nil(t, r)
+	assert.Empty(t, contentType)
+
+	body, err = encodeBody(map[string]string{"a": "b"})
+	require.NoError(t, err)
+	r, contentType, err = body.open()
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	assert.JSONEq(t, `{"a":"b"}`, string(data))
+	assert.Equal(t, "application/json", contentType)
+
+	body, err = encodeBody(&FileUpload{Filename: `x"1.png`, Content: strings.NewReader("png")})
+	require.NoError(t, err)
+	r, contentType, err = body.open()
+	require.NoError(t, err)
+	data, _ = io.ReadAll(r)
+	body.close()
+	assert.Contains(t, contentType, "multipart/form-data; boundary=")
+	assert.Contains(t, string(data), `filename="x\"1.png"`)
+	assert.Contains(t, string(data), "Content-Type: image/png")
+
+	_, err = encodeBody(make(chan int))
+	require.Error(t, err)
+}
+
+func TestDetectContentType(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 16)
+	tests := []struct {
+		filename    string
+		contentType string
+		content     string
+		want        string
+	}{
+		{"photo.jpg", "", "data", "image/jpeg"},
+		{"PHOTO.PNG", "", "data", "image/png"},
+		{"icon.svg", "", "<svg/>", "image/svg+xml"},
+		{"upload", "", png, "image/png"},
+		{"upload", "", "plain text", "text/plain"},
+		{"photo.jpg", "image/webp", "data", "image/webp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename+" "+tt.want, func(t *testing.T) {
+			server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				_, contentType, data := readUpload(t, r)
+				assert.Equal(t, tt.want, contentType)
+				assert.Equal(t, tt.content, string(data))
+				json.NewEncoder(w).Encode(ImagesResponse{Images: []Image{{URL: "https://example.com/x"}}})
+			})
+			defer server.Close()
+
+			_, err := client.UploadImageWithOptions(context.Background(), strings.NewReader(tt.content), tt.filename,
+				&UploadOptions{ContentType: tt.contentType})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_UploadImage_Streams(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "big.jpg")
+	content := bytes.Repeat([]byte("0123456789"), 100_000)
+	require.NoError(t, os.WriteFile(path, content, 0644))
+
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// Streamed bodies are sent without a precomputed length.
+		assert.Equal(t, int64(-1), r.ContentLength)
+		filename, contentType, data := readUpload(t, r)
+		assert.Equal(t, "big.jpg", filename)
+		assert.Equal(t, "image/jpeg", contentType)
+		assert.Equal(t, content, data)
+		json.NewEncoder(w).Encode(ImagesResponse{Images: []Image{{URL: "https://example.com/big.jpg"}}})
+	})
+	defer server.Close()
+
+	resp, err := client.UploadImage(path)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/big.jpg", resp.Images[0].URL)
+}
+
+func TestClient_UploadImage_Progress(t *testing.T) {
+	server, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		readUpload(t, r)
+		json.NewEncoder(w).Encode(ImagesResponse{Images: []Image{{URL: "https://example.com/x.png"}}})
+	})
+	defer server.Close()
+
+	content := bytes.Repeat([]byte("x"), 100_000)
+	var sent, total []int64
+	progress := func(s, t int64) {
+		sent = append(sent, s)
+		total = append(total, t)
+	}
+
+	_, err := client.UploadImageWithOptions(context.Background(), bytes.NewReader(content), "x.png",
+		&UploadOptions{Progress: progress})
+	require.NoError(t, err)
+	require.NotEmpty(t, sent)
+	assert.Equal(t, int64(len(content)), sent[len(sent)-1])
+	assert.Equal(t, int64(len(content)), total[0])
+
+	// Plain readers have no known size.
+	sent, total = nil, nil
+	_, err = client.UploadImageWithOptions(context.Background(), io.MultiReader(bytes.NewReader(content)), "x.png",
+		&UploadOptions{Progress: progress})
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), sent[len(sent)-1])
+	assert.Equal(t, int64(-1), total[0])
+}
+
+func TestClient_UploadImage_MaxSize(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		io.Copy(io.Discard, r.Body)
+		json.NewEncoder(w).Encode(ImagesResponse{Images: []Image{{URL: "https://example.com/x.png"}}})
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, testAPIKey, WithMaxUploadSize(10))
+
+	// A known size is rejected before anything is sent.
+	_, err := client.UploadImageReader(strings.NewReader("more than ten bytes"), "x.png")
+	require.ErrorIs(t, err, ErrUploadTooLarge)
+	assert.Contains(t, err.Error(), "upload failed")
+	assert.Equal(t, int32(0), requests.Load())
+
+	// An unknown size is cut off while streaming.
+	_, err = client.UploadImageReader(io.MultiReader(strings.NewReader("more than ten bytes")), "x.png")
+	require.ErrorIs(t, err, ErrUploadTooLarge)
+
+	// Per-upload options override the client default.
+	_, err = client.UploadImageWithOptions(context.Background(), strings.NewReader("more than ten bytes"), "x.png",
+		&UploadOptions{MaxSize: 100})
+	require.NoError(t, err)
+}
+
+func TestClient_UploadImage_RetrySeekable(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _, data := readUpload(t, r)
+		assert.Equal(t, "image data", string(data))
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(ImagesResponse{Images: []Image{{URL: "https://example.com/x.png"}}})
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, RetryNonIdempotent: true}
+	client := NewClient(server.URL, testAPIKey, WithRetryPolicy(policy))
+
+	_, err := client.UploadImageReader(strings.NewReader("image data"), "x.png")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Readers that cannot be rewound are sent only once.
+	requests.Store(0)
+	_, err = client.UploadImageReader(io.MultiReader(strings.NewReader("image data")), "x.png")
+	require.Error(t, err)
+	var respErr *ResponseError
+	require.True(t, errors.As(err, &respErr))
+	assert.Equal(t, http.StatusServiceUnavailable, respErr.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestContentSize(t *testing.T) {
+	assert.Equal(t, int64(5), contentSize(strings.NewReader("hello")))
+	assert.Equal(t, int64(0), contentSize(io.MultiReader(strings.NewReader("hello"))))
+
+	path := filepath.Join(t.TempDir(), "f")
+	require.NoError(t, os.WriteFile(path, []byte("hello world"), 0644))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	assert.Equal(t, int64(11), contentSize(f))
+	_, err = f.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), contentSize(f))
+}
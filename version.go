@@ -0,0 +1,226 @@
+package libecto
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// Version is a Ghost version number such as 5.82.1.
+// Ghost reports versions as "major.minor" in most places; Patch is 0 when absent.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses versions like "5.82", "v5.82.1" or "5.82.1-beta".
+// Any pre-release or build suffix is ignored.
+func ParseVersion(s string) (Version, error) {
+	raw := s
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", raw)
+	}
+
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", raw)
+		}
+		nums[i] = n
+	}
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+// String returns the version in "major.minor.patch" form.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is older than,
+// equal to or newer than o.
+func (v Version) Compare(o Version) int {
+	for _, d := range [...]int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether v is the same as or newer than o.
+func (v Version) AtLeast(o Version) bool {
+	return v.Compare(o) >= 0
+}
+
+// Feature names a Ghost capability that is only available on newer installs.
+type Feature string
+
+// Features that can be checked with Client.Supports.
+const (
+	// FeatureTiers is the tiers API, which replaced products.
+	FeatureTiers Feature = "tiers"
+	// FeatureNewsletters is support for multiple newsletters.
+	FeatureNewsletters Feature = "newsletters"
+	// FeatureLexical is the lexical document format for posts and pages.
+	FeatureLexical Feature = "lexical"
+	// FeatureRecommendations is the recommendations API.
+	FeatureRecommendations Feature = "recommendations"
+)
+
+// featureVersions maps each Feature to the first Ghost version that provides it.
+var featureVersions = map[Feature]Version{
+	FeatureTiers:           {Major: 5},
+	FeatureNewsletters:     {Major: 5},
+	FeatureLexical:         {Major: 5, Minor: 41},
+	FeatureRecommendations: {Major: 5, Minor: 71},
+}
+
+// MinVersion returns the first Ghost version that provides the feature.
+// The boolean is false for unknown features.
+func (f Feature) MinVersion() (Version, bool) {
+	v, ok := featureVersions[f]
+	return v, ok
+}
+
+// WithAcceptVersion pins the API version sent in the Accept-Version header
+// on every request (e.g., "v5.0"). Ghost then keeps responses compatible with
+// that version and reports deprecated behavior through response headers.
+// A leading "v" is added if missing.
+func WithAcceptVersion(version string) ClientOption {
+	return func(c *Client) {
+		if version != "" && !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		c.acceptVersion = version
+	}
+}
+
+// Deprecation describes deprecation headers returned by Ghost for a request.
+type Deprecation struct {
+	// Method is the HTTP method of the request.
+	Method string
+	// Path is the request path relative to the API base URL.
+	Path string
+	// Deprecation is the Deprecation header value.
+	Deprecation string
+	// Sunset is the Sunset header value, if any.
+	Sunset string
+	// Link is the Link header value, usually pointing to migration docs.
+	Link string
+	// Warning is the Warning header value, if any.
+	Warning string
+}
+
+// WithDeprecationHandler registers fn to be called whenever a response carries
+// Deprecation or Warning headers, typically because the request relies on
+// behavior that is deprecated in the pinned Accept-Version.
+// Deprecations are also logged at warn level when a logger is configured.
+func WithDeprecationHandler(fn func(ctx context.Context, d Deprecation)) ClientOption {
+	return func(c *Client) {
+		c.onDeprecation = fn
+	}
+}
+
+// ContentVersion returns the Content-Version header from the most recent
+// response, which is the API version the server used (e.g., "v5.82").
+// It is empty until a response has been received.
+func (c *Client) ContentVersion() string {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+	return c.contentVersion
+}
+
+// observeVersion records the Content-Version header and reports deprecations.
+func (c *Client) observeVersion(ctx context.Context, req *Request, resp *Response) {
+	if v := resp.Header.Get("Content-Version"); v != "" {
+		c.versionMu.Lock()
+		c.contentVersion = v
+		c.versionMu.Unlock()
+	}
+
+	d := Deprecation{
+		Method:      req.Method,
+		Path:        req.Path,
+		Deprecation: resp.Header.Get("Deprecation"),
+		Sunset:      resp.Header.Get("Sunset"),
+		Link:        resp.Header.Get("Link"),
+		Warning:     resp.Header.Get("Warning"),
+	}
+	if d.Deprecation == "" && d.Warning == "" {
+		return
+	}
+	if c.logger != nil {
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "ghost api deprecation",
+			slog.String("method", d.Method),
+			slog.String("path", redactPath(d.Path)),
+			slog.String("deprecation", d.Deprecation),
+			slog.String("sunset", d.Sunset),
+			slog.String("link", d.Link),
+			slog.String("warning", d.Warning),
+		)
+	}
+	if c.onDeprecation != nil {
+		c.onDeprecation(ctx, d)
+	}
+}
+
+// ServerVersion returns the Ghost version of the site, from GetSite.
+// The result is cached for the lifetime of the client.
+func (c *Client) ServerVersion() (Version, error) {
+	return c.ServerVersionContext(context.Background())
+}
+
+// ServerVersionContext is like ServerVersion but uses ctx for the request.
+func (c *Client) ServerVersionContext(ctx context.Context) (Version, error) {
+	c.versionMu.Lock()
+	cached := c.siteVersion
+	c.versionMu.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+
+	site, err := c.GetSiteContext(ctx)
+	if err != nil {
+		return Version{}, err
+	}
+	v, err := ParseVersion(site.Version)
+	if err != nil {
+		return Version{}, err
+	}
+
+	c.versionMu.Lock()
+	c.siteVersion = &v
+	c.versionMu.Unlock()
+	return v, nil
+}
+
+// Supports reports whether the site's Ghost version provides feature.
+// It returns an error for unknown features or if the version cannot be determined.
+func (c *Client) Supports(feature Feature) (bool, error) {
+	return c.SupportsContext(context.Background(), feature)
+}
+
+// SupportsContext is like Supports but uses ctx for the request.
+func (c *Client) SupportsContext(ctx context.Context, feature Feature) (bool, error) {
+	min, ok := feature.MinVersion()
+	if !ok {
+		return false, fmt.Errorf("unknown feature %q", feature)
+	}
+	v, err := c.ServerVersionContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	return v.AtLeast(min), nil
+}
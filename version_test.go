@@ -0,0 +1,195 @@
+package libecto
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Version
+		wantErr bool
+	}{
+		{"5.82", Version{5, 82, 0}, false},
+		{"v5.82.1", Version{5, 82, 1}, false},
+		{"5.0.0-beta.1", Version{5, 0, 0}, false},
+		{"6", Version{6, 0, 0}, false},
+		{" 5.1 ", Version{5, 1, 0}, false},
+		{"", Version{}, true},
+		{"five", Version{}, true},
+		{"5.x", Version{}, true},
+		{"1.2.3.4", Version{}, true},
+		{"5.-1", Version{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseVersion(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	v := Version{5, 41, 0}
+	assert.Equal(t, 0, v.Compare(Version{5, 41, 0}))
+	assert.Equal(t, 1, v.Compare(Version{5, 40, 9}))
+	assert.Equal(t, -1, v.Compare(Version{5, 41, 1}))
+	assert.Equal(t, -1, v.Compare(Version{6, 0, 0}))
+	assert.True(t, v.AtLeast(Version{5, 0, 0}))
+	assert.False(t, v.AtLeast(Version{5, 71, 0}))
+	assert.Equal(t, "5.41.0", v.String())
+}
+
+func TestFeature_MinVersion(t *testing.T) {
+	v, ok := FeatureLexical.MinVersion()
+	assert.True(t, ok)
+	assert.Equal(t, Version{5, 41, 0}, v)
+
+	_, ok = Feature("teleport").MinVersion()
+	assert.False(t, ok)
+}
+
+func TestWithAcceptVersion(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Accept-Version"))
+		w.Write([]byte(`{"site":{}}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, testAPIKey).GetSite()
+	require.NoError(t, err)
+	_, err = NewClient(server.URL, testAPIKey, WithAcceptVersion("v5.0")).GetSite()
+	require.NoError(t, err)
+	_, err = NewClient(server.URL, testAPIKey, WithAcceptVersion("5.82")).GetSite()
+	require.NoError(t, err)
+	_, err = NewContentClient(server.URL, testContentKey, WithAcceptVersion("v5.0")).GetSettings()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", "v5.0", "v5.82", "v5.0"}, got)
+}
+
+func TestWithAcceptVersion_MiddlewareOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "v4.0", r.Header.Get("Accept-Version"))
+		w.Write([]byte(`{"site":{}}`))
+	}))
+	defer server.Close()
+
+	override := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("Accept-Version", "v4.0")
+			return next(ctx, req)
+		}
+	}
+	client := NewClient(server.URL, testAPIKey, WithAcceptVersion("v5.0"), WithMiddleware(override))
+	_, err := client.GetSite()
+	require.NoError(t, err)
+}
+
+func TestClient_ContentVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Version", "v5.82")
+		w.Write([]byte(`{"tags":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testAPIKey)
+	assert.Empty(t, client.ContentVersion())
+	_, err := client.ListTags(0)
+	require.NoError(t, err)
+	assert.Equal(t, "v5.82", client.ContentVersion())
+}
+
+func TestWithDeprecationHandler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ghost/api/admin/posts/" {
+			w.Header().Set("Deprecation", `version="v5.0"`)
+			w.Header().Set("Link", `<https://ghost.org/docs/>; rel="deprecation"`)
+			w.Header().Set("Warning", `299 - "Use lexical"`)
+		}
+		w.Write([]byte(`{"posts":[],"tags":[]}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	var got []Deprecation
+	client := NewClient(server.URL, testAPIKey,
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithDeprecationHandler(func(ctx context.Context, d Deprecation) {
+			got = append(got, d)
+		}))
+
+	_, err := client.ListTags(0)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = client.ListPosts("", 0)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "GET", got[0].Method)
+	assert.Equal(t, "/posts/?formats=html", got[0].Path)
+	assert.Equal(t, `version="v5.0"`, got[0].Deprecation)
+	assert.Contains(t, got[0].Link, "rel=\"deprecation\"")
+	assert.Equal(t, `299 - "Use lexical"`, got[0].Warning)
+	assert.Contains(t, buf.String(), `"msg":"ghost api deprecation"`)
+}
+
+func TestClient_Supports(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "/ghost/api/admin/site/", r.URL.Path)
+		w.Write([]byte(`{"site":{"version":"5.50"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testAPIKey)
+	ok, err := client.Supports(FeatureLexical)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = client.Supports(FeatureRecommendations)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = client.Supports(Feature("teleport"))
+	assert.Error(t, err)
+
+	v, err := client.ServerVersion()
+	require.NoError(t, err)
+	assert.Equal(t, Version{5, 50, 0}, v)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_Supports_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"site":{"version":"unknown"}}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, testAPIKey).Supports(FeatureTiers)
+	assert.Error(t, err)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer failing.Close()
+
+	_, err = NewClient(failing.URL, testAPIKey).Supports(FeatureTiers)
+	assert.True(t, IsUnauthorized(err))
+}